@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,17 +33,25 @@ type AccessListAuthorization struct {
 	// Password defines the authentication password for HTTP Basic Authentication.
 	// This password will be paired with the username for authentication.
 	// Must be between 1 and 255 characters in length.
+	// Deprecated: the password is stored in clear in the resource, use PasswordSecretRef instead.
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=255
-	// +required
-	Password string `json:"password"`
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// PasswordSecretRef selects the key of a Secret holding the password, in the namespace of the AccessList.
+	// The access list is updated when the Secret changes.
+	// When neither Password nor PasswordSecretRef is set, the current password of the user in NPM is kept.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
 }
 
 type AccessListClient struct {
 	// Address specifies the IPv4 address or CIDR subnet for IP-based access control.
 	// Format: Single IP (e.g., "192.168.1.1") or CIDR notation (e.g., "192.168.0.0/24").
 	// Used in conjunction with the Directive field to allow or deny access.
-	// +kubebuilder:validation:Required
+	// Exactly one of Address and Source must be set.
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern=`^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])(\/([0-9]|[1-2][0-9]|3[0-2]))?$`
 	// +required
@@ -55,6 +64,63 @@ type AccessListClient struct {
 	// +kubebuilder:validation:Enum=allow;deny
 	// +required
 	Directive string `json:"directive,omitempty"`
+
+	// Source expands the client into the addresses read from Kubernetes objects, all with the same directive.
+	// The access list is updated when those objects change.
+	// +optional
+	Source *AccessListClientSource `json:"source,omitempty"`
+}
+
+// AccessListClientSource selects the Kubernetes objects the addresses of a client are read from.
+// Exactly one source must be set.
+type AccessListClientSource struct {
+	// Nodes expands into the addresses of the selected nodes.
+	// +optional
+	Nodes *AccessListNodesSource `json:"nodes,omitempty"`
+
+	// PodCIDR expands into the pod CIDRs allocated to the selected nodes.
+	// +optional
+	PodCIDR *AccessListPodCIDRSource `json:"podCIDR,omitempty"`
+
+	// ConfigMapKeyRef expands into the addresses listed in a key of a ConfigMap in the namespace
+	// of the AccessList, one IP address or CIDR per line. Empty lines and lines starting with "#" are ignored.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// Service expands into the load balancer ingress IPs of a Service of type LoadBalancer.
+	// +optional
+	Service *AccessListServiceSource `json:"service,omitempty"`
+}
+
+type AccessListNodesSource struct {
+	// Selector selects the nodes by their labels, all nodes are selected when it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// AddressTypes lists the types of node addresses to use.
+	// +kubebuilder:default:={"InternalIP"}
+	// +optional
+	AddressTypes []NodeAddressType `json:"addressTypes,omitempty"`
+}
+
+// NodeAddressType is the type of a node address used by an access list client.
+// +kubebuilder:validation:Enum=InternalIP;ExternalIP
+type NodeAddressType string
+
+type AccessListPodCIDRSource struct {
+	// Selector selects the nodes by their labels, all nodes are selected when it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+type AccessListServiceSource struct {
+	// Name of the Service.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the Service, the namespace of the AccessList when not set.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
 }
 
 // AccessListSpec defines the desired state of AccessList.
@@ -72,6 +138,12 @@ type AccessListSpec struct {
 	// +Optional
 	Token *TokenName `json:"token,omitempty"`
 
+	// ListName is the name of the access list in NPM, the name of the resource when not set.
+	// It is used to bind an existing access list whose name is not a valid resource name.
+	// +kubebuilder:validation:Optional
+	// +optional
+	ListName string `json:"listName,omitempty"`
+
 	// SatisfyAny controls how multiple access control methods are evaluated.
 	// When true: Access is granted if ANY condition is met (logical OR).
 	// When false: Access requires ALL conditions to be met (logical AND).
@@ -92,6 +164,17 @@ type AccessListSpec struct {
 	// +optional
 	PassAuth bool `json:"passAuth,omitempty"`
 
+	// BindExisting controls the operator's behavior with existing NPM access lists.
+	// When true (default): Updates the existing access list named after the resource, or listName.
+	// Its original configuration is restored when the resource is deleted instead of deleting
+	// a list other hosts may use. The passwords of the users removed by the operator can't be restored.
+	// When false: Always creates a new access list.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	BindExisting bool `json:"bindExisting,omitempty"`
+
 	// Authorizations defines the list of username/password pairs for HTTP Basic Authentication.
 	// These credentials will be required to access resources protected by this AccessList.
 	// Based on Nginx HTTP Basic Authentication module.
@@ -99,12 +182,63 @@ type AccessListSpec struct {
 	// +optional
 	Authorizations []AccessListAuthorization `json:"authorizations,omitempty"`
 
+	// AuthorizationsSecretRef selects the key of a Secret holding more users, one "username:password"
+	// line each, in the namespace of the AccessList. NPM hashes the passwords itself, they must be in clear.
+	// Empty lines and lines starting with "#" are ignored. The access list is updated when the Secret changes.
+	// +optional
+	AuthorizationsSecretRef *corev1.SecretKeySelector `json:"authorizationsSecretRef,omitempty"`
+
 	// Clients defines IP-based access control rules using allow/deny directives.
 	// Each entry specifies an IP address or subnet with an associated action.
 	// Rules are evaluated in order. Based on Nginx HTTP Access module.
 	// +kubebuilder:validation:Type=array
 	// +optional
 	Clients []AccessListClient `json:"clients,omitempty"`
+
+	// DriftPolicy controls what the operator does when the access list is changed in NPM outside of the operator.
+	// "Ignore": changes are neither reported nor corrected.
+	// "Report": changes are reported by the Drifted condition.
+	// "Correct" (default): changes are reported and reverted to the spec.
+	// An access list deleted in NPM is recreated whatever the policy.
+	// +kubebuilder:default:=Correct
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Ignore;Report;Correct
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	// DeletionPolicy controls what happens to the access list in NPM when the resource is deleted.
+	// "Delete": the access list is deleted.
+	// "Orphan": the access list is left untouched, other hosts keep using it.
+	// "Restore": the configuration captured when the access list was bound is restored, it requires bindExisting.
+	// When not set, access lists created by the operator are deleted and bound ones are restored,
+	// or left untouched when no configuration was captured.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Orphan;Restore
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Suspend stops the reconciliation of the resource, the access list in NPM is left as is until it is resumed.
+	// The resource can also be suspended with the nginxpm-operator.io/paused: "true" annotation.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// AccessListInitialConfiguration stores the original NPM access list configuration
+// before any mutations by the operator. Used to restore settings when
+// a bound resource is deleted.
+type AccessListInitialConfiguration struct {
+	// SatisfyAny from the original configuration
+	SatisfyAny bool `json:"satisfyAny,omitempty"`
+
+	// PassAuth from the original configuration
+	PassAuth bool `json:"passAuth,omitempty"`
+
+	// Usernames of the original items, NPM does not return their passwords
+	Usernames []string `json:"usernames,omitempty"`
+
+	// Clients from the original configuration
+	Clients []AccessListClient `json:"clients,omitempty"`
 }
 
 // AccessListStatus defines the observed state of AccessList.
@@ -121,6 +255,32 @@ type AccessListStatus struct {
 	// +kubebuilder:default:=0
 	ProxyHostCount int `json:"proxyHostCount,omitempty"`
 
+	// ProxyHosts lists the ProxyHost resources referencing this AccessList, as "namespace/name".
+	// The access list is not deleted from NPM while it is referenced, see the InUse condition.
+	// +optional
+	ProxyHosts []string `json:"proxyHosts,omitempty"`
+
+	// Bound indicates if this resource was linked to an existing NPM access list.
+	// When true, the operator found and adopted an existing access list with the same name.
+	// When false, a new access list was created in NPM.
+	// +kubebuilder:default:=false
+	// +optional
+	Bound bool `json:"bound,omitempty"`
+
+	// InitialConfiguration stores the original NPM access list configuration captured when
+	// binding to an existing access list, it is restored when the resource is deleted.
+	// +optional
+	InitialConfiguration *AccessListInitialConfiguration `json:"initialConfiguration,omitempty"`
+
+	// AppliedConfigHash is the hash of the configuration last sent to NPM for this access list.
+	// +optional
+	AppliedConfigHash string `json:"appliedConfigHash,omitempty"`
+
+	// TokenRef is the Token resource used to reach the Nginx Proxy Manager instance
+	// managing this resource, as resolved by the operator.
+	// +optional
+	TokenRef *TokenName `json:"tokenRef,omitempty"`
+
 	// Conditions represent the current state of the AccessList resource.
 	// Common condition types include "Ready", "Synced", and "Error".
 	// The "Ready" condition indicates if the AccessList is successfully configured in NPM.
@@ -132,6 +292,10 @@ type AccessListStatus struct {
 // +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id"
 // +kubebuilder:printcolumn:name="Name",type="string",JSONPath=".spec.name"
 // +kubebuilder:printcolumn:name="Proxy Host Count",type="integer",JSONPath=".status.proxyHostCount"
+// +kubebuilder:printcolumn:name="Bound",type="boolean",JSONPath=".status.bound"
+// +kubebuilder:printcolumn:name="In Use",type="string",JSONPath=".status.conditions[?(@.type==\"InUse\")].status",priority=1
+// +kubebuilder:printcolumn:name="Token",type="string",JSONPath=".status.tokenRef.name"
+// +kubebuilder:printcolumn:name="Token Namespace",type="string",JSONPath=".status.tokenRef.namespace",priority=1
 
 // AccessList is the Schema for the accesslists API.
 type AccessList struct {
@@ -0,0 +1,64 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterTokenSpec defines the desired state of ClusterToken
+type ClusterTokenSpec struct {
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Endpoint and Secret of the Nginx Proxy Manager instance.
+	// The Secret is looked up in the namespace the operator runs in.
+	TokenSpec `json:",inline"`
+
+	// AllowedNamespaces selects the namespaces whose resources may use this ClusterToken.
+	// An empty selector ({}) allows every namespace, while an unset selector allows none.
+	// +kubebuilder:validation:Optional
+	// +optional
+	AllowedNamespaces *metav1.LabelSelector `json:"allowedNamespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secret.secretName"
+// +kubebuilder:printcolumn:name="Expires",type="string",JSONPath=".status.expires"
+
+// ClusterToken is the Schema for the clustertokens API
+type ClusterToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterTokenSpec `json:"spec,omitempty"`
+	Status TokenStatus      `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterTokenList contains a list of ClusterToken
+type ClusterTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterToken `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterToken{}, &ClusterTokenList{})
+}
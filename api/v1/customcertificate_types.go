@@ -53,6 +53,21 @@ type CustomCertificateSpec struct {
 	// +Optional
 	Token *TokenName `json:"token,omitempty"`
 
+	// DeletionPolicy controls what happens to the certificate in NPM when the resource is deleted.
+	// "Delete": the certificate is deleted.
+	// "Orphan": the certificate is left untouched and can still be attached to hosts.
+	// When not set, the certificate is deleted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Suspend stops the reconciliation of the resource, the certificate in NPM is left as is until it is resumed.
+	// The resource can also be suspended with the nginxpm-operator.io/paused: "true" annotation.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
 	// NiceName provides a human-readable display name for the certificate.
 	// If not specified, the CustomCertificate resource name will be used.
 	// This name appears in the Nginx Proxy Manager UI for easier identification.
@@ -91,6 +106,11 @@ type CustomCertificateStatus struct {
 	// +optional
 	Status *string `json:"status,omitempty"`
 
+	// TokenRef is the Token resource used to reach the Nginx Proxy Manager instance
+	// managing this resource, as resolved by the operator.
+	// +optional
+	TokenRef *TokenName `json:"tokenRef,omitempty"`
+
 	// Conditions represent the current state of the CustomCertificate resource.
 	// Common condition types include "Ready", "Valid", and "Synced".
 	// The "Ready" condition indicates if the certificate is successfully configured in NPM.
@@ -102,6 +122,8 @@ type CustomCertificateStatus struct {
 // +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id"
 // +kubebuilder:printcolumn:name="ExpiresOn",type="string",JSONPath=".status.expiresOn"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status"
+// +kubebuilder:printcolumn:name="Token",type="string",JSONPath=".status.tokenRef.name"
+// +kubebuilder:printcolumn:name="Token Namespace",type="string",JSONPath=".status.tokenRef.namespace",priority=1
 
 // CustomCertificate is the Schema for the customcertificates API
 type CustomCertificate struct {
@@ -0,0 +1,154 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeadHostSpec defines the desired state of DeadHost
+type DeadHostSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Token references the authentication token for the Nginx Proxy Manager API.
+	// If not provided, the operator will search for a token named "token-nginxpm" in:
+	// 1. The same namespace as this DeadHost
+	// 2. The "nginxpm-operator-system" namespace
+	// 3. The "default" namespace
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Token *TokenName `json:"token,omitempty"`
+
+	// DomainNames lists the domains that will answer with a 404 page.
+	// Supports standard domains ("example.com") and wildcards ("*.example.com").
+	// All domains must point to the Nginx Proxy Manager instance.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=array
+	// +required
+	DomainNames []DomainName `json:"domainNames,omitempty"`
+
+	// BindExisting controls the operator's behavior with existing NPM 404 hosts.
+	// When true (default): Updates existing 404 hosts with matching domains.
+	// When false: Always creates new 404 hosts, may cause conflicts.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	BindExisting bool `json:"bindExisting,omitempty"`
+
+	// DeletionPolicy controls what happens to the 404 host in NPM when the resource is deleted.
+	// "Delete": the 404 host is deleted.
+	// "Orphan": the 404 host is left untouched.
+	// "Disable": the 404 host is disabled.
+	// When not set, 404 hosts created by the operator are deleted and bound ones are disabled.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Orphan;Disable
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Suspend stops the reconciliation of the resource, the 404 host in NPM is left as is until it is resumed.
+	// The resource can also be suspended with the nginxpm-operator.io/paused: "true" annotation.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Ssl configures SSL/TLS settings for the parked domains.
+	// Shares the same options as the ProxyHost SSL configuration.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Ssl *ProxyHostSsl `json:"ssl,omitempty"`
+
+	// AdvancedConfig contains raw Nginx configuration directives.
+	// Injected directly into the server block. Use with caution.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +optional
+	AdvancedConfig string `json:"advancedConfig,omitempty"`
+}
+
+// DeadHostStatus defines the observed state of DeadHost
+type DeadHostStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Id represents the unique identifier assigned by the Nginx Proxy Manager instance.
+	// This field is populated after successful creation/synchronization with NPM.
+	Id *int `json:"id,omitempty"`
+
+	// CertificateId indicates the SSL certificate ID currently used by this 404 host.
+	CertificateId *int `json:"certificateId,omitempty"`
+
+	// Bound indicates if this resource was linked to an existing NPM 404 host.
+	// When true, the record is disabled instead of deleted when the resource is removed.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	Bound bool `json:"bound,omitempty"`
+
+	// Online reflects the 404 host's operational status in NPM.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	Online bool `json:"online,omitempty"`
+
+	// TokenRef is the Token resource used to reach the Nginx Proxy Manager instance
+	// managing this resource, as resolved by the operator.
+	// +optional
+	TokenRef *TokenName `json:"tokenRef,omitempty"`
+
+	// Conditions represent the current state of the DeadHost resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="Online",type="boolean",JSONPath=".status.online"
+// +kubebuilder:printcolumn:name="CertificateId",type="string",JSONPath=".status.certificateId"
+// +kubebuilder:printcolumn:name="Domains",type="string",JSONPath=".spec.domainNames"
+// +kubebuilder:printcolumn:name="Bound",type="boolean",JSONPath=".status.bound"
+// +kubebuilder:printcolumn:name="Token",type="string",JSONPath=".status.tokenRef.name"
+// +kubebuilder:printcolumn:name="Token Namespace",type="string",JSONPath=".status.tokenRef.namespace",priority=1
+
+// DeadHost is the Schema for the deadhosts API
+type DeadHost struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DeadHostSpec   `json:"spec,omitempty"`
+	Status DeadHostStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DeadHostList contains a list of DeadHost
+type DeadHostList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeadHost `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DeadHost{}, &DeadHostList{})
+}
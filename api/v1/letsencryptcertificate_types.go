@@ -82,6 +82,22 @@ type LetsEncryptCertificateSpec struct {
 	// +optional
 	Token *TokenName `json:"token,omitempty"`
 
+	// DeletionPolicy controls what happens to the certificate in NPM when the resource is deleted.
+	// "Delete": the certificate is deleted.
+	// "Orphan": the certificate is left untouched and keeps being renewed by NPM.
+	// When not set, certificates requested by the operator are deleted and bound ones are left untouched.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Suspend stops the reconciliation of the resource, the certificate in NPM is left as is until it is resumed.
+	// The resource can also be suspended with the nginxpm-operator.io/paused: "true" annotation.
+	// NPM keeps renewing the certificate meanwhile.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
 	// DomainNames lists the domain names to include in the Let's Encrypt certificate.
 	// Supports wildcards (e.g., "*.example.com") and multiple domains.
 	// All domains must be under your control for validation to succeed.
@@ -102,6 +118,13 @@ type LetsEncryptCertificateSpec struct {
 	// +required
 	LetsEncryptEmail string `json:"letsEncryptEmail,omitempty"`
 
+	// RenewBefore is how long before its expiry the operator asks NPM to renew the certificate.
+	// NPM renews its certificates on its own about 30 days before they expire, the operator
+	// only steps in when that did not happen. Defaults to 14 days (336h).
+	// +kubebuilder:validation:Optional
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
 	// DnsChallenge configures DNS-01 challenge for domain validation.
 	// Required for wildcard certificates or when HTTP-01 challenge is not feasible.
 	// When not specified, HTTP-01 challenge is used by default.
@@ -145,8 +168,23 @@ type LetsEncryptCertificateStatus struct {
 	// +optional
 	ExpiresOn *string `json:"expiresOn,omitempty"`
 
+	// LastRenewed is when the operator last renewed the certificate.
+	// Renewals made by NPM on its own are only seen through expiresOn.
+	// +optional
+	LastRenewed *metav1.Time `json:"lastRenewed,omitempty"`
+
+	// LastRenewalAttempt is when the operator last asked NPM to renew the certificate,
+	// a failed renewal is only retried an hour later to stay within the Let's Encrypt rate limits.
+	// +optional
+	LastRenewalAttempt *metav1.Time `json:"lastRenewalAttempt,omitempty"`
+
+	// TokenRef is the Token resource used to reach the Nginx Proxy Manager instance
+	// managing this resource, as resolved by the operator.
+	// +optional
+	TokenRef *TokenName `json:"tokenRef,omitempty"`
+
 	// Conditions represent the current state of the LetsEncryptCertificate resource.
-	// Common condition types include "Ready", "Issued", "Renewing", and "ValidationFailed".
+	// Common condition types include "Ready", "Renewing", "RenewalFailed" and "Drifted".
 	// The "Ready" condition indicates if the certificate is successfully issued and active.
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
@@ -157,6 +195,9 @@ type LetsEncryptCertificateStatus struct {
 // +kubebuilder:printcolumn:name="DomainNames",type="string",JSONPath=".spec.domainNames"
 // +kubebuilder:printcolumn:name="Bound",type="boolean",JSONPath=".status.bound"
 // +kubebuilder:printcolumn:name="ExpiresOn",type="string",JSONPath=".status.expiresOn"
+// +kubebuilder:printcolumn:name="Last Renewed",type="date",JSONPath=".status.lastRenewed",priority=1
+// +kubebuilder:printcolumn:name="Token",type="string",JSONPath=".status.tokenRef.name"
+// +kubebuilder:printcolumn:name="Token Namespace",type="string",JSONPath=".status.tokenRef.namespace",priority=1
 
 // LetsEncryptCertificate is the Schema for the letsencryptcertificates API
 type LetsEncryptCertificate struct {
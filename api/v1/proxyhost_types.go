@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -316,6 +317,44 @@ type ProxyHostAccessList struct {
 	Namespace *string `json:"namespace,omitempty"`
 }
 
+type ProxyHostAccessRules struct {
+	// SatisfyAny grants access when either the client address or the basic auth is accepted.
+	// When false, both are required.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +optional
+	SatisfyAny bool `json:"satisfyAny,omitempty"`
+
+	// PassAuth forwards the basic auth header to the upstream server.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +optional
+	PassAuth bool `json:"passAuth,omitempty"`
+
+	// Clients allows or denies IP addresses and CIDR subnets, evaluated in order.
+	// +optional
+	Clients []ProxyHostAccessClient `json:"clients,omitempty"`
+
+	// AuthorizationsSecretRef selects the key of a Secret holding the basic auth users, one "username:password"
+	// line each, in the namespace of the ProxyHost. The passwords must be in clear, NPM hashes them itself.
+	// +optional
+	AuthorizationsSecretRef *corev1.SecretKeySelector `json:"authorizationsSecretRef,omitempty"`
+}
+
+type ProxyHostAccessClient struct {
+	// Address is an IPv4 address or a CIDR subnet, e.g. "192.168.1.1" or "192.168.0.0/24".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])\.){3}([0-9]|[1-9][0-9]|1[0-9]{2}|2[0-4][0-9]|25[0-5])(\/([0-9]|[1-2][0-9]|3[0-2]))?$`
+	// +required
+	Address string `json:"address"`
+
+	// Directive allows or denies the address.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=allow;deny
+	// +required
+	Directive string `json:"directive"`
+}
+
 // ProxyHostSpec defines the desired state of ProxyHost
 type ProxyHostSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -341,6 +380,14 @@ type ProxyHostSpec struct {
 	// +required
 	DomainNames []DomainName `json:"domainNames,omitempty"`
 
+	// Enabled controls whether the proxy host is enabled in NPM, it is enabled when not set.
+	// When false, the proxy host is kept in NPM but disabled and stops serving traffic.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// BindExisting controls the operator's behavior with existing NPM proxy hosts.
 	// When true (default): Updates existing proxy hosts with matching domains.
 	// When false: Always creates new proxy hosts, may cause conflicts.
@@ -350,6 +397,36 @@ type ProxyHostSpec struct {
 	// +optional
 	BindExisting bool `json:"bindExisting,omitempty"`
 
+	// DriftPolicy controls what the operator does when the proxy host is changed outside of the operator,
+	// for instance from the Nginx Proxy Manager UI. The live proxy host is compared to the spec periodically.
+	// "Ignore": changes are neither reported nor corrected.
+	// "Report": changes are reported by the Drifted condition.
+	// "Correct" (default): changes are reported and reverted to the spec.
+	// +kubebuilder:default:=Correct
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Ignore;Report;Correct
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	// DeletionPolicy controls what happens to the proxy host in NPM when the resource is deleted.
+	// "Delete": the proxy host is deleted.
+	// "Orphan": the proxy host is left untouched, for instance when moving it to another resource.
+	// "Disable": the proxy host is disabled.
+	// "Restore": the configuration captured when the proxy host was bound is restored, it requires bindExisting.
+	// When not set, proxy hosts created by the operator are deleted and bound ones are restored,
+	// or disabled when no configuration was captured.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Orphan;Disable;Restore
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Suspend stops the reconciliation of the resource, the proxy host in NPM is left as is until it is resumed.
+	// The resource can also be suspended with the nginxpm-operator.io/paused: "true" annotation.
+	// Use spec.enabled instead to take the proxy host offline.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
 	// CachingEnabled activates Nginx caching for improved performance.
 	// When true, static content and responses are cached according to cache headers.
 	// Default is false. Enable for better performance with cacheable content.
@@ -385,6 +462,13 @@ type ProxyHostSpec struct {
 	// +optional
 	AccessList *ProxyHostAccessList `json:"accessList,omitempty"`
 
+	// AccessRules protects the proxy host with an access list created in NPM for it alone, named after the ProxyHost.
+	// The access list is deleted when the rules are removed or the ProxyHost is deleted.
+	// Use an AccessList resource instead to share rules between proxy hosts, AccessList and AccessRules are exclusive.
+	// +kubebuilder:validation:Optional
+	// +optional
+	AccessRules *ProxyHostAccessRules `json:"accessRules,omitempty"`
+
 	// Ssl configures SSL/TLS settings for this proxy host.
 	// Controls certificate management, HTTPS redirection, and security headers.
 	// If not specified, defaults to automatic Let's Encrypt certificate.
@@ -408,6 +492,14 @@ type ProxyHostSpec struct {
 	// +kubebuilder:validation:Type=array
 	// +optional
 	CustomLocations []CustomLocation `json:"customLocations,omitempty"`
+
+	// UnscopedConfig contains raw Nginx configuration written outside of the server block,
+	// through the unscoped_config field of the paradoxe35/nginx-proxy-manager fork.
+	// The upstreams generated by the operator are written after it. Ignored by other NPM versions.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +optional
+	UnscopedConfig string `json:"unscopedConfig,omitempty"`
 }
 
 // InitialConfiguration stores the original NPM proxy host configuration
@@ -504,6 +596,19 @@ type ProxyHostStatus struct {
 	// +optional
 	Online bool `json:"online,omitempty"`
 
+	// AccessRulesListId is the id of the NPM access list created for the access rules of the spec.
+	// +optional
+	AccessRulesListId *int `json:"accessRulesListId,omitempty"`
+
+	// AccessRulesConfigHash is the hash of the access rules last sent to NPM.
+	// +optional
+	AccessRulesConfigHash string `json:"accessRulesConfigHash,omitempty"`
+
+	// AppliedConfigHash is the hash of the configuration last sent to NPM for this proxy host.
+	// Drift is only looked for while the configuration built from the spec keeps this hash.
+	// +optional
+	AppliedConfigHash string `json:"appliedConfigHash,omitempty"`
+
 	// InitialConfiguration stores the original NPM proxy host configuration
 	// captured when binding to an existing proxy host. This is used to restore
 	// the original settings when the resource is deleted and was bound to an
@@ -520,6 +625,11 @@ type ProxyHostStatus struct {
 	// - "CertificateReady": SSL certificate is valid and active
 	// Each condition includes status (True/False/Unknown), reason, and message fields.
 
+	// TokenRef is the Token resource used to reach the Nginx Proxy Manager instance
+	// managing this resource, as resolved by the operator.
+	// +optional
+	TokenRef *TokenName `json:"tokenRef,omitempty"`
+
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
@@ -530,6 +640,10 @@ type ProxyHostStatus struct {
 // +kubebuilder:printcolumn:name="CertificateId",type="string",JSONPath=".status.certificateId"
 // +kubebuilder:printcolumn:name="Domains",type="string",JSONPath=".spec.domainNames"
 // +kubebuilder:printcolumn:name="Bound",type="boolean",JSONPath=".status.bound"
+// +kubebuilder:printcolumn:name="Enabled",type="boolean",JSONPath=".spec.enabled",priority=1
+// +kubebuilder:printcolumn:name="Token",type="string",JSONPath=".status.tokenRef.name"
+// +kubebuilder:printcolumn:name="Token Namespace",type="string",JSONPath=".status.tokenRef.namespace",priority=1
+// +kubebuilder:printcolumn:name="Drifted",type="string",JSONPath=".status.conditions[?(@.type==\"Drifted\")].status",priority=1
 
 // ProxyHost is the Schema for the proxyhosts API
 type ProxyHost struct {
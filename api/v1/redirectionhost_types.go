@@ -0,0 +1,264 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RedirectionHostForward struct {
+	// Scheme defines the protocol used in the redirect location.
+	// "auto" keeps the scheme of the incoming request, "http" and "https" force it.
+	// Default is "auto".
+	// +kubebuilder:default:=auto
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=auto;http;https
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+
+	// DomainName is the target domain clients are redirected to.
+	// Must be a plain domain name without scheme, e.g. "www.example.com".
+	// The request path is appended when PreservePath is true.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)*\.[a-zA-Z]{2,}$`
+	// +required
+	DomainName string `json:"domainName,omitempty"`
+
+	// HttpCode is the HTTP status code returned with the redirect.
+	// Use 301/308 for permanent redirects and 302/307 for temporary ones.
+	// Default is 301 (Moved Permanently).
+	// +kubebuilder:default:=301
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=integer
+	// +kubebuilder:validation:Enum=300;301;302;303;307;308
+	// +optional
+	HttpCode int `json:"httpCode,omitempty"`
+
+	// PreservePath keeps the request URI when redirecting.
+	// When true, "example.com/foo" redirects to "target.com/foo".
+	// When false (default), every request redirects to the target root.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	PreservePath bool `json:"preservePath,omitempty"`
+}
+
+// RedirectionHostSpec defines the desired state of RedirectionHost
+type RedirectionHostSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Token references the authentication token for the Nginx Proxy Manager API.
+	// If not provided, the operator will search for a token named "token-nginxpm" in:
+	// 1. The same namespace as this RedirectionHost
+	// 2. The "nginxpm-operator-system" namespace
+	// 3. The "default" namespace
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Token *TokenName `json:"token,omitempty"`
+
+	// DomainNames lists the domains that will be redirected.
+	// Supports standard domains ("example.com") and wildcards ("*.example.com").
+	// All domains must point to the Nginx Proxy Manager instance.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=array
+	// +required
+	DomainNames []DomainName `json:"domainNames,omitempty"`
+
+	// BindExisting controls the operator's behavior with existing NPM redirection hosts.
+	// When true (default): Updates existing redirection hosts with matching domains.
+	// When false: Always creates new redirection hosts, may cause conflicts.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	BindExisting bool `json:"bindExisting,omitempty"`
+
+	// DeletionPolicy controls what happens to the redirection host in NPM when the resource is deleted.
+	// "Delete": the redirection host is deleted.
+	// "Orphan": the redirection host is left untouched as last reconciled.
+	// "Disable": the redirection host is disabled.
+	// "Restore": the configuration captured when the redirection host was bound is restored, it requires bindExisting.
+	// When not set, redirection hosts created by the operator are deleted and bound ones are restored,
+	// or disabled when no configuration was captured.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Orphan;Disable;Restore
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Suspend stops the reconciliation of the resource, the redirection host in NPM is left as is until it is resumed.
+	// The resource can also be suspended with the nginxpm-operator.io/paused: "true" annotation.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Forward defines where and how incoming requests are redirected.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=object
+	// +required
+	Forward RedirectionHostForward `json:"forward,omitempty"`
+
+	// BlockExploits enables common exploit protection rules.
+	// Blocks various SQL injection, XSS, and other common web exploits.
+	// Default is true. Only disable if it causes issues with legitimate traffic.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	BlockExploits bool `json:"blockExploits,omitempty"`
+
+	// Ssl configures SSL/TLS settings for the redirected domains.
+	// Shares the same options as the ProxyHost SSL configuration.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +optional
+	Ssl *ProxyHostSsl `json:"ssl,omitempty"`
+
+	// AdvancedConfig contains raw Nginx configuration directives.
+	// Injected directly into the server block. Use with caution.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=string
+	// +optional
+	AdvancedConfig string `json:"advancedConfig,omitempty"`
+}
+
+// RedirectionHostInitialConfiguration stores the original NPM redirection host
+// configuration before any mutations by the operator. Used to restore settings
+// when a bound resource is deleted.
+type RedirectionHostInitialConfiguration struct {
+	// DomainNames from the original NPM configuration
+	DomainNames []string `json:"domainNames,omitempty"`
+
+	// ForwardScheme from the original configuration
+	ForwardScheme string `json:"forwardScheme,omitempty"`
+
+	// ForwardDomainName from the original configuration
+	ForwardDomainName string `json:"forwardDomainName,omitempty"`
+
+	// ForwardHttpCode from the original configuration
+	ForwardHttpCode int `json:"forwardHttpCode,omitempty"`
+
+	// PreservePath from the original configuration
+	PreservePath bool `json:"preservePath,omitempty"`
+
+	// CertificateId from the original configuration
+	CertificateId *int `json:"certificateId,omitempty"`
+
+	// SSLForced from the original configuration
+	SSLForced bool `json:"sslForced,omitempty"`
+
+	// BlockExploits from the original configuration
+	BlockExploits bool `json:"blockExploits,omitempty"`
+
+	// HTTP2Support from the original configuration
+	HTTP2Support bool `json:"http2Support,omitempty"`
+
+	// HSTSEnabled from the original configuration
+	HSTSEnabled bool `json:"hstsEnabled,omitempty"`
+
+	// HSTSSubdomains from the original configuration
+	HSTSSubdomains bool `json:"hstsSubdomains,omitempty"`
+
+	// AdvancedConfig from the original configuration
+	AdvancedConfig string `json:"advancedConfig,omitempty"`
+
+	// Enabled status from the original configuration
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// RedirectionHostStatus defines the observed state of RedirectionHost
+type RedirectionHostStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Id represents the unique identifier assigned by the Nginx Proxy Manager instance.
+	// This field is populated after successful creation/synchronization with NPM.
+	Id *int `json:"id,omitempty"`
+
+	// CertificateId indicates the SSL certificate ID currently used by this redirection host.
+	CertificateId *int `json:"certificateId,omitempty"`
+
+	// Bound indicates if this resource was linked to an existing NPM redirection host.
+	// When true, the operator found and adopted an existing redirection host with matching domains.
+	// When false, a new redirection host was created in NPM.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	Bound bool `json:"bound,omitempty"`
+
+	// Online reflects the redirection host's operational status in NPM.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	Online bool `json:"online,omitempty"`
+
+	// InitialConfiguration stores the original NPM redirection host configuration
+	// captured when binding to an existing redirection host. It is restored when
+	// the resource is deleted.
+	// +kubebuilder:validation:Optional
+	// +optional
+	InitialConfiguration *RedirectionHostInitialConfiguration `json:"initialConfiguration,omitempty"`
+
+	// TokenRef is the Token resource used to reach the Nginx Proxy Manager instance
+	// managing this resource, as resolved by the operator.
+	// +optional
+	TokenRef *TokenName `json:"tokenRef,omitempty"`
+
+	// Conditions represent the current state of the RedirectionHost resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id"
+// +kubebuilder:printcolumn:name="Online",type="boolean",JSONPath=".status.online"
+// +kubebuilder:printcolumn:name="Domains",type="string",JSONPath=".spec.domainNames"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.forward.domainName"
+// +kubebuilder:printcolumn:name="Code",type="integer",JSONPath=".spec.forward.httpCode"
+// +kubebuilder:printcolumn:name="Bound",type="boolean",JSONPath=".status.bound"
+// +kubebuilder:printcolumn:name="Token",type="string",JSONPath=".status.tokenRef.name"
+// +kubebuilder:printcolumn:name="Token Namespace",type="string",JSONPath=".status.tokenRef.namespace",priority=1
+
+// RedirectionHost is the Schema for the redirectionhosts API
+type RedirectionHost struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RedirectionHostSpec   `json:"spec,omitempty"`
+	Status RedirectionHostStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RedirectionHostList contains a list of RedirectionHost
+type RedirectionHostList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedirectionHost `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RedirectionHost{}, &RedirectionHostList{})
+}
@@ -107,6 +107,16 @@ type StreamSpec struct {
 	// +required
 	IncomingPort int `json:"incomingPort,omitempty"`
 
+	// BindExisting controls the operator's behavior with existing NPM streams.
+	// When true (default): Updates the existing stream listening on the incoming port.
+	// Its original configuration is restored when the resource is deleted.
+	// When false: Always creates a new stream, which fails if the port is already used.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	BindExisting bool `json:"bindExisting,omitempty"`
+
 	// OverwriteIncomingPortWithForwardPort allows automatic port matching.
 	// When true, the incoming port is set to match the forwarding port.
 	// Useful for transparent proxying where ports should remain the same.
@@ -131,6 +141,61 @@ type StreamSpec struct {
 	// +kubebuilder:validation:Type=object
 	// +optional
 	Ssl *StreamSsl `json:"ssl,omitempty"`
+
+	// DriftPolicy controls what the operator does when the stream is changed in NPM outside of the operator.
+	// "Ignore": changes are neither reported nor corrected.
+	// "Report": changes are reported by the Drifted condition.
+	// "Correct" (default): changes are reported and reverted to the spec.
+	// A stream deleted in NPM is recreated whatever the policy.
+	// +kubebuilder:default:=Correct
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Ignore;Report;Correct
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	// DeletionPolicy controls what happens to the stream in NPM when the resource is deleted.
+	// "Delete": the stream is deleted.
+	// "Orphan": the stream is left untouched and keeps forwarding traffic.
+	// "Disable": the stream is disabled.
+	// "Restore": the configuration captured when the stream was bound is restored, it requires bindExisting.
+	// When not set, streams created by the operator are deleted and bound ones are restored,
+	// or disabled when no configuration was captured.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Orphan;Disable;Restore
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+
+	// Suspend stops the reconciliation of the resource, the stream in NPM is left as is until it is resumed.
+	// The resource can also be suspended with the nginxpm-operator.io/paused: "true" annotation.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// StreamInitialConfiguration stores the original NPM stream configuration
+// before any mutations by the operator. Used to restore settings when
+// a bound resource is deleted.
+type StreamInitialConfiguration struct {
+	// IncomingPort from the original configuration
+	IncomingPort int `json:"incomingPort,omitempty"`
+
+	// ForwardingHost from the original configuration
+	ForwardingHost string `json:"forwardingHost,omitempty"`
+
+	// ForwardingPort from the original configuration
+	ForwardingPort int `json:"forwardingPort,omitempty"`
+
+	// TCPForwarding from the original configuration
+	TCPForwarding bool `json:"tcpForwarding,omitempty"`
+
+	// UDPForwarding from the original configuration
+	UDPForwarding bool `json:"udpForwarding,omitempty"`
+
+	// CertificateId from the original configuration
+	CertificateId *int `json:"certificateId,omitempty"`
+
+	// Enabled status from the original configuration
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // StreamStatus defines the observed state of Stream.
@@ -152,6 +217,20 @@ type StreamStatus struct {
 	// Useful for debugging connection issues.
 	ForwardingPort *int `json:"forwardingPort,omitempty"`
 
+	// Bound indicates if this resource was linked to an existing NPM stream.
+	// When true, the operator found and adopted an existing stream on the incoming port.
+	// When false, a new stream was created in NPM.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=boolean
+	// +optional
+	Bound bool `json:"bound,omitempty"`
+
+	// InitialConfiguration stores the original NPM stream configuration captured when
+	// binding to an existing stream, it is restored when the resource is deleted.
+	// +optional
+	InitialConfiguration *StreamInitialConfiguration `json:"initialConfiguration,omitempty"`
+
 	// Online reflects the stream's operational status in NPM.
 	// True indicates the stream is active and forwarding traffic.
 	// False may indicate configuration errors or port conflicts.
@@ -161,6 +240,15 @@ type StreamStatus struct {
 	// +optional
 	Online bool `json:"online,omitempty"`
 
+	// AppliedConfigHash is the hash of the configuration last sent to NPM for this stream.
+	// +optional
+	AppliedConfigHash string `json:"appliedConfigHash,omitempty"`
+
+	// TokenRef is the Token resource used to reach the Nginx Proxy Manager instance
+	// managing this resource, as resolved by the operator.
+	// +optional
+	TokenRef *TokenName `json:"tokenRef,omitempty"`
+
 	// Conditions represent the current state of the Stream resource.
 	// Common condition types include "Ready", "PortAvailable", and "Synced".
 	// The "Ready" condition indicates if the stream is successfully configured and active.
@@ -175,6 +263,9 @@ type StreamStatus struct {
 // +kubebuilder:printcolumn:name="Forwarding",type="integer",JSONPath=".status.forwardingPort"
 // +kubebuilder:printcolumn:name="TCP",type="boolean",JSONPath=".spec.forward.tcpForwarding"
 // +kubebuilder:printcolumn:name="UDP",type="boolean",JSONPath=".spec.forward.udpForwarding"
+// +kubebuilder:printcolumn:name="Bound",type="boolean",JSONPath=".status.bound"
+// +kubebuilder:printcolumn:name="Token",type="string",JSONPath=".status.tokenRef.name"
+// +kubebuilder:printcolumn:name="Token Namespace",type="string",JSONPath=".status.tokenRef.namespace",priority=1
 
 // Stream is the Schema for the streams API.
 type Stream struct {
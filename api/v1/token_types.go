@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -37,6 +38,13 @@ type TokenName struct {
 	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
 	// +optional
 	Namespace *string `json:"namespace,omitempty"`
+
+	// Kind of the referenced resource, either Token or ClusterToken.
+	// Defaults to Token. A ClusterToken is cluster-scoped, so Namespace is ignored for it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Token;ClusterToken
+	// +optional
+	Kind string `json:"kind,omitempty"`
 }
 
 // SecretData is the data of the secret resource
@@ -91,13 +99,24 @@ type TokenStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	// Token contains the JWT authentication token from Nginx Proxy Manager.
-	// This token is automatically generated and refreshed by the operator.
-	// Used internally for API authentication - do not modify manually.
+	// Deprecated: the token is now stored in the Secret referenced by TokenSecretRef,
+	// values left by previous versions of the operator are cleared on the next reconciliation.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Type=string
 	// +optional
 	Token *string `json:"token,omitempty"`
 
+	// TokenSecretRef references the Secret holding the JWT authentication token from Nginx Proxy Manager.
+	// The Secret is owned by the operator and the token is stored under its "token" key.
+	// This token is automatically generated and refreshed by the operator.
+	// +optional
+	TokenSecretRef *corev1.SecretReference `json:"tokenSecretRef,omitempty"`
+
+	// IssuedAt indicates when the current JWT token was created or last refreshed.
+	// Used with Expires to refresh the token before the end of its lifetime.
+	// +optional
+	IssuedAt *metav1.Time `json:"issuedAt,omitempty"`
+
 	// Expires indicates when the current JWT token will expire.
 	// The operator automatically refreshes tokens before expiration.
 	// Format: Kubernetes metav1.Time (RFC3339).
@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -55,6 +56,11 @@ func (in *AccessList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessListAuthorization) DeepCopyInto(out *AccessListAuthorization) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessListAuthorization.
@@ -70,6 +76,11 @@ func (in *AccessListAuthorization) DeepCopy() *AccessListAuthorization {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessListClient) DeepCopyInto(out *AccessListClient) {
 	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(AccessListClientSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessListClient.
@@ -82,6 +93,68 @@ func (in *AccessListClient) DeepCopy() *AccessListClient {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessListClientSource) DeepCopyInto(out *AccessListClientSource) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(AccessListNodesSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodCIDR != nil {
+		in, out := &in.PodCIDR, &out.PodCIDR
+		*out = new(AccessListPodCIDRSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(AccessListServiceSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessListClientSource.
+func (in *AccessListClientSource) DeepCopy() *AccessListClientSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessListClientSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessListInitialConfiguration) DeepCopyInto(out *AccessListInitialConfiguration) {
+	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]AccessListClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessListInitialConfiguration.
+func (in *AccessListInitialConfiguration) DeepCopy() *AccessListInitialConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessListInitialConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessListList) DeepCopyInto(out *AccessListList) {
 	*out = *in
@@ -114,6 +187,71 @@ func (in *AccessListList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessListNodesSource) DeepCopyInto(out *AccessListNodesSource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AddressTypes != nil {
+		in, out := &in.AddressTypes, &out.AddressTypes
+		*out = make([]NodeAddressType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessListNodesSource.
+func (in *AccessListNodesSource) DeepCopy() *AccessListNodesSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessListNodesSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessListPodCIDRSource) DeepCopyInto(out *AccessListPodCIDRSource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessListPodCIDRSource.
+func (in *AccessListPodCIDRSource) DeepCopy() *AccessListPodCIDRSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessListPodCIDRSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessListServiceSource) DeepCopyInto(out *AccessListServiceSource) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessListServiceSource.
+func (in *AccessListServiceSource) DeepCopy() *AccessListServiceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessListServiceSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessListSpec) DeepCopyInto(out *AccessListSpec) {
 	*out = *in
@@ -125,12 +263,21 @@ func (in *AccessListSpec) DeepCopyInto(out *AccessListSpec) {
 	if in.Authorizations != nil {
 		in, out := &in.Authorizations, &out.Authorizations
 		*out = make([]AccessListAuthorization, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AuthorizationsSecretRef != nil {
+		in, out := &in.AuthorizationsSecretRef, &out.AuthorizationsSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Clients != nil {
 		in, out := &in.Clients, &out.Clients
 		*out = make([]AccessListClient, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -152,6 +299,21 @@ func (in *AccessListStatus) DeepCopyInto(out *AccessListStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.ProxyHosts != nil {
+		in, out := &in.ProxyHosts, &out.ProxyHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitialConfiguration != nil {
+		in, out := &in.InitialConfiguration, &out.InitialConfiguration
+		*out = new(AccessListInitialConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -171,6 +333,86 @@ func (in *AccessListStatus) DeepCopy() *AccessListStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterToken) DeepCopyInto(out *ClusterToken) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterToken.
+func (in *ClusterToken) DeepCopy() *ClusterToken {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterToken) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTokenList) DeepCopyInto(out *ClusterTokenList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTokenList.
+func (in *ClusterTokenList) DeepCopy() *ClusterTokenList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTokenList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTokenList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTokenSpec) DeepCopyInto(out *ClusterTokenSpec) {
+	*out = *in
+	out.TokenSpec = in.TokenSpec
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTokenSpec.
+func (in *ClusterTokenSpec) DeepCopy() *ClusterTokenSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTokenSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomCertificate) DeepCopyInto(out *CustomCertificate) {
 	*out = *in
@@ -305,6 +547,11 @@ func (in *CustomCertificateStatus) DeepCopyInto(out *CustomCertificateStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -340,6 +587,132 @@ func (in *CustomLocation) DeepCopy() *CustomLocation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadHost) DeepCopyInto(out *DeadHost) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadHost.
+func (in *DeadHost) DeepCopy() *DeadHost {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeadHost) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadHostList) DeepCopyInto(out *DeadHostList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeadHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadHostList.
+func (in *DeadHostList) DeepCopy() *DeadHostList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadHostList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeadHostList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadHostSpec) DeepCopyInto(out *DeadHostSpec) {
+	*out = *in
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DomainNames != nil {
+		in, out := &in.DomainNames, &out.DomainNames
+		*out = make([]DomainName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ssl != nil {
+		in, out := &in.Ssl, &out.Ssl
+		*out = new(ProxyHostSsl)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadHostSpec.
+func (in *DeadHostSpec) DeepCopy() *DeadHostSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadHostSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadHostStatus) DeepCopyInto(out *DeadHostStatus) {
+	*out = *in
+	if in.Id != nil {
+		in, out := &in.Id, &out.Id
+		*out = new(int)
+		**out = **in
+	}
+	if in.CertificateId != nil {
+		in, out := &in.CertificateId, &out.CertificateId
+		*out = new(int)
+		**out = **in
+	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadHostStatus.
+func (in *DeadHostStatus) DeepCopy() *DeadHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DnsChallenge) DeepCopyInto(out *DnsChallenge) {
 	*out = *in
@@ -534,6 +907,11 @@ func (in *LetsEncryptCertificateSpec) DeepCopyInto(out *LetsEncryptCertificateSp
 		*out = make([]DomainName, len(*in))
 		copy(*out, *in)
 	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.DnsChallenge != nil {
 		in, out := &in.DnsChallenge, &out.DnsChallenge
 		*out = new(DnsChallenge)
@@ -569,6 +947,19 @@ func (in *LetsEncryptCertificateStatus) DeepCopyInto(out *LetsEncryptCertificate
 		*out = new(string)
 		**out = **in
 	}
+	if in.LastRenewed != nil {
+		in, out := &in.LastRenewed, &out.LastRenewed
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRenewalAttempt != nil {
+		in, out := &in.LastRenewalAttempt, &out.LastRenewalAttempt
+		*out = (*in).DeepCopy()
+	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -615,6 +1006,21 @@ func (in *ProxyHost) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyHostAccessClient) DeepCopyInto(out *ProxyHostAccessClient) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyHostAccessClient.
+func (in *ProxyHostAccessClient) DeepCopy() *ProxyHostAccessClient {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyHostAccessClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyHostAccessList) DeepCopyInto(out *ProxyHostAccessList) {
 	*out = *in
@@ -640,6 +1046,31 @@ func (in *ProxyHostAccessList) DeepCopy() *ProxyHostAccessList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyHostAccessRules) DeepCopyInto(out *ProxyHostAccessRules) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]ProxyHostAccessClient, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthorizationsSecretRef != nil {
+		in, out := &in.AuthorizationsSecretRef, &out.AuthorizationsSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyHostAccessRules.
+func (in *ProxyHostAccessRules) DeepCopy() *ProxyHostAccessRules {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyHostAccessRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyHostForward) DeepCopyInto(out *ProxyHostForward) {
 	*out = *in
@@ -725,11 +1156,21 @@ func (in *ProxyHostSpec) DeepCopyInto(out *ProxyHostSpec) {
 		*out = make([]DomainName, len(*in))
 		copy(*out, *in)
 	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AccessList != nil {
 		in, out := &in.AccessList, &out.AccessList
 		*out = new(ProxyHostAccessList)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccessRules != nil {
+		in, out := &in.AccessRules, &out.AccessRules
+		*out = new(ProxyHostAccessRules)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Ssl != nil {
 		in, out := &in.Ssl, &out.Ssl
 		*out = new(ProxyHostSsl)
@@ -803,11 +1244,21 @@ func (in *ProxyHostStatus) DeepCopyInto(out *ProxyHostStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.AccessRulesListId != nil {
+		in, out := &in.AccessRulesListId, &out.AccessRulesListId
+		*out = new(int)
+		**out = **in
+	}
 	if in.InitialConfiguration != nil {
 		in, out := &in.InitialConfiguration, &out.InitialConfiguration
 		*out = new(InitialConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -827,6 +1278,178 @@ func (in *ProxyHostStatus) DeepCopy() *ProxyHostStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectionHost) DeepCopyInto(out *RedirectionHost) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectionHost.
+func (in *RedirectionHost) DeepCopy() *RedirectionHost {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectionHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedirectionHost) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectionHostForward) DeepCopyInto(out *RedirectionHostForward) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectionHostForward.
+func (in *RedirectionHostForward) DeepCopy() *RedirectionHostForward {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectionHostForward)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectionHostInitialConfiguration) DeepCopyInto(out *RedirectionHostInitialConfiguration) {
+	*out = *in
+	if in.DomainNames != nil {
+		in, out := &in.DomainNames, &out.DomainNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateId != nil {
+		in, out := &in.CertificateId, &out.CertificateId
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectionHostInitialConfiguration.
+func (in *RedirectionHostInitialConfiguration) DeepCopy() *RedirectionHostInitialConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectionHostInitialConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectionHostList) DeepCopyInto(out *RedirectionHostList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RedirectionHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectionHostList.
+func (in *RedirectionHostList) DeepCopy() *RedirectionHostList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectionHostList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedirectionHostList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectionHostSpec) DeepCopyInto(out *RedirectionHostSpec) {
+	*out = *in
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DomainNames != nil {
+		in, out := &in.DomainNames, &out.DomainNames
+		*out = make([]DomainName, len(*in))
+		copy(*out, *in)
+	}
+	out.Forward = in.Forward
+	if in.Ssl != nil {
+		in, out := &in.Ssl, &out.Ssl
+		*out = new(ProxyHostSsl)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectionHostSpec.
+func (in *RedirectionHostSpec) DeepCopy() *RedirectionHostSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectionHostSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedirectionHostStatus) DeepCopyInto(out *RedirectionHostStatus) {
+	*out = *in
+	if in.Id != nil {
+		in, out := &in.Id, &out.Id
+		*out = new(int)
+		**out = **in
+	}
+	if in.CertificateId != nil {
+		in, out := &in.CertificateId, &out.CertificateId
+		*out = new(int)
+		**out = **in
+	}
+	if in.InitialConfiguration != nil {
+		in, out := &in.InitialConfiguration, &out.InitialConfiguration
+		*out = new(RedirectionHostInitialConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedirectionHostStatus.
+func (in *RedirectionHostStatus) DeepCopy() *RedirectionHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedirectionHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Secret) DeepCopyInto(out *Secret) {
 	*out = *in
@@ -949,6 +1572,26 @@ func (in *StreamForward) DeepCopy() *StreamForward {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamInitialConfiguration) DeepCopyInto(out *StreamInitialConfiguration) {
+	*out = *in
+	if in.CertificateId != nil {
+		in, out := &in.CertificateId, &out.CertificateId
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamInitialConfiguration.
+func (in *StreamInitialConfiguration) DeepCopy() *StreamInitialConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamInitialConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StreamList) DeepCopyInto(out *StreamList) {
 	*out = *in
@@ -1055,6 +1698,16 @@ func (in *StreamStatus) DeepCopyInto(out *StreamStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.InitialConfiguration != nil {
+		in, out := &in.InitialConfiguration, &out.InitialConfiguration
+		*out = new(StreamInitialConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(TokenName)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1177,6 +1830,15 @@ func (in *TokenStatus) DeepCopyInto(out *TokenStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	if in.IssuedAt != nil {
+		in, out := &in.IssuedAt, &out.IssuedAt
+		*out = (*in).DeepCopy()
+	}
 	if in.Expires != nil {
 		in, out := &in.Expires, &out.Expires
 		*out = (*in).DeepCopy()
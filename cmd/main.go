@@ -19,7 +19,9 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -34,14 +36,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
 	"github.com/paradoxe35/nginxpm-operator/internal/controller/accesslist"
 	"github.com/paradoxe35/nginxpm-operator/internal/controller/customcertificate"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/deadhost"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/gateway"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/ingress"
 	"github.com/paradoxe35/nginxpm-operator/internal/controller/letsencryptcertificate"
 	"github.com/paradoxe35/nginxpm-operator/internal/controller/proxyhost"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/redirectionhost"
 	"github.com/paradoxe35/nginxpm-operator/internal/controller/stream"
 	"github.com/paradoxe35/nginxpm-operator/internal/controller/token"
+	"github.com/paradoxe35/nginxpm-operator/internal/importer"
+	webhooknginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/internal/webhook/v1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -54,15 +66,33 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(nginxpmoperatoriov1.AddToScheme(scheme))
+
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
+	// The import command writes the resources managing the objects of an Nginx Proxy Manager instance
+	if len(os.Args) > 1 && os.Args[1] == importer.COMMAND {
+		if err := importer.Run(ctrl.SetupSignalHandler(), os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var ingressClassName string
+	var enableGatewayAPI bool
+	var tokenRefreshRatio float64
+	var clientAccessCheckTTL time.Duration
+	var resyncPeriod time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -74,6 +104,17 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&ingressClassName, "ingress-class", ingress.DEFAULT_INGRESS_CLASS,
+		"The ingressClassName of the Ingress resources translated into ProxyHost resources.")
+	flag.BoolVar(&enableGatewayAPI, "enable-gateway-api", false,
+		"If set, HTTPRoute, TCPRoute and UDPRoute resources of Gateways whose GatewayClass controllerName is "+
+			string(gateway.CONTROLLER_NAME)+" are translated. The Gateway API CRDs must be installed.")
+	flag.Float64Var(&tokenRefreshRatio, "token-refresh-ratio", token.DEFAULT_TOKEN_REFRESH_RATIO,
+		"The fraction of the lifetime of Nginx Proxy Manager tokens after which they are refreshed, between 0 and 1.")
+	flag.DurationVar(&clientAccessCheckTTL, "client-access-check-ttl", controller.DEFAULT_CLIENT_ACCESS_CHECK_TTL,
+		"How long the token access check of the Nginx Proxy Manager clients shared by the controllers is trusted.")
+	flag.DurationVar(&resyncPeriod, "resync-period", controller.DEFAULT_RESYNC_PERIOD,
+		"How often resources are compared with Nginx Proxy Manager to recreate or report the objects changed there, 0 disables it.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -82,6 +123,14 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if tokenRefreshRatio <= 0 || tokenRefreshRatio > 1 {
+		setupLog.Error(nil, "invalid token refresh ratio, it must be greater than 0 and at most 1", "ratio", tokenRefreshRatio)
+		os.Exit(1)
+	}
+
+	controller.ClientPool.AccessCheckTTL = clientAccessCheckTTL
+	controller.ResyncPeriod = resyncPeriod
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -150,12 +199,21 @@ func main() {
 	}
 
 	if err = (&token.TokenReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		RefreshRatio: tokenRefreshRatio,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Token")
 		os.Exit(1)
 	}
+	if err = (&token.ClusterTokenReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		RefreshRatio: tokenRefreshRatio,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterToken")
+		os.Exit(1)
+	}
 	if err = (&proxyhost.ProxyHostReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -196,6 +254,103 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Stream")
 		os.Exit(1)
 	}
+	if err = (&redirectionhost.RedirectionHostReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("redirectionhost-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RedirectionHost")
+		os.Exit(1)
+	}
+	if err = (&deadhost.DeadHostReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("deadhost-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DeadHost")
+		os.Exit(1)
+	}
+	if err = (&ingress.IngressReconciler{
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		Recorder:         mgr.GetEventRecorderFor("ingress-controller"),
+		IngressClassName: ingressClassName,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Ingress")
+		os.Exit(1)
+	}
+	if enableGatewayAPI {
+		if err = (&gateway.GatewayClassReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GatewayClass")
+			os.Exit(1)
+		}
+		if err = (&gateway.HTTPRouteReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("httproute-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HTTPRoute")
+			os.Exit(1)
+		}
+		if err = (&gateway.TCPRouteReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("tcproute-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "TCPRoute")
+			os.Exit(1)
+		}
+		if err = (&gateway.UDPRouteReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("udproute-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "UDPRoute")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhooknginxpmoperatoriov1.SetupTokenWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Token")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupClusterTokenWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterToken")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupProxyHostWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ProxyHost")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupLetsEncryptCertificateWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "LetsEncryptCertificate")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupCustomCertificateWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CustomCertificate")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupAccessListWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AccessList")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupStreamWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Stream")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupRedirectionHostWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RedirectionHost")
+			os.Exit(1)
+		}
+		if err = webhooknginxpmoperatoriov1.SetupDeadHostWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DeadHost")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -19,8 +19,11 @@ package accesslist
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -46,6 +50,14 @@ const (
 	accessListFinalizer = "accesslist.nginxpm-operator.io/finalizers"
 
 	ACL_TOKEN_FIELD = ".spec.token.name"
+
+	// ACL_SECRET_FIELD indexes the Secrets holding the passwords of the access list
+	ACL_SECRET_FIELD = ".spec.secretRefs"
+
+	// Fields indexing the objects the clients of the access list are read from
+	ACL_NODES_FIELD     = ".spec.clients.source.nodes"
+	ACL_CONFIGMAP_FIELD = ".spec.clients.source.configMapKeyRef.name"
+	ACL_SERVICE_FIELD   = ".spec.clients.source.service"
 )
 
 // AccessListReconciler reconciles a AccessList object
@@ -60,6 +72,9 @@ type AccessListReconciler struct {
 // +kubebuilder:rbac:groups=nginxpm-operator.io,resources=accesslists/finalizers,verbs=update
 // +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens,verbs=get;list;watch
 // +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens/status,verbs=get
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=proxyhosts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes;configmaps;services,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -95,6 +110,18 @@ func (r *AccessListReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	// Leave the access list in NPM alone while the reconciliation is suspended,
+	// the finalizer is kept so a deletion waits until the resource is resumed
+	if controller.IsSuspended(acl, acl.Spec.Suspend) {
+		log.Info("Reconciliation is suspended, skipping")
+
+		controller.UpdateStatus(ctx, r.Client, acl, req.NamespacedName, func() {
+			controller.SetSuspendedCondition(&acl.Status.Conditions, acl.Generation, true)
+		})
+
+		return ctrl.Result{}, nil
+	}
+
 	// Let's just set the status as Unknown when no status is available
 	if len(acl.Status.Conditions) == 0 {
 		controller.UpdateStatus(ctx, r.Client, acl, req.NamespacedName, func() {
@@ -109,7 +136,7 @@ func (r *AccessListReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	// Create a new Nginx Proxy Manager client
-	nginxpmClient, err := controller.InitNginxPMClient(ctx, r, req, acl.Spec.Token)
+	nginxpmClient, tokenRef, err := controller.InitNginxPMClient(ctx, r, req, acl.Spec.Token)
 	if err != nil {
 		if isMarkedToBeDeleted {
 			// Remove the finalizer
@@ -145,10 +172,49 @@ func (r *AccessListReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			log.Info("Performing Finalizer Operations for AccessList")
 
 			if acl.Status.Id != nil {
-				// Delete access list here
-				err := nginxpmClient.DeleteAccessList(int(*acl.Status.Id))
-				if err != nil {
-					log.Error(err, "Failed to delete access list from remote NPM")
+				// A bound access list may be used by other hosts, it is restored or left as is by default
+				deletionPolicy := controller.DeletionPolicy(acl.Spec.DeletionPolicy, acl.Status.Bound, ShouldRestoreInitialConfig(acl), controller.DELETION_POLICY_ORPHAN)
+
+				switch deletionPolicy {
+				case controller.DELETION_POLICY_RESTORE:
+					log.Info("Restoring initial configuration for bound AccessList", "accessListId", *acl.Status.Id)
+
+					restorationInput := BuildRestorationInput(listName(acl), acl.Status.InitialConfiguration)
+					_, err := nginxpmClient.UpdateAccessListContext(ctx, *acl.Status.Id, *restorationInput)
+					if err != nil {
+						log.Error(err, "Failed to restore initial configuration for AccessList")
+					}
+				case controller.DELETION_POLICY_DELETE:
+					// Keep the access list while proxy hosts use it, the resource is deleted once they stop
+					references, err := r.referencingProxyHosts(ctx, acl)
+					if err != nil {
+						log.Error(err, "Failed to list the ProxyHosts referencing the access list")
+						return ctrl.Result{RequeueAfter: time.Minute}, err
+					}
+
+					if len(references) > 0 {
+						log.Info("AccessList is still referenced, waiting before deleting it from remote NPM", "proxyHosts", references)
+
+						r.Recorder.Event(
+							acl, "Warning", "InUse",
+							fmt.Sprintf("Access list is referenced by ProxyHosts, ResourceName: %s, Namespace: %s, proxyHosts: %s",
+								req.Name, req.Namespace, strings.Join(references, ", ")),
+						)
+
+						controller.UpdateStatus(ctx, r.Client, acl, req.NamespacedName, func() {
+							acl.Status.ProxyHosts = references
+							setInUseCondition(&acl.Status.Conditions, acl.Generation, references)
+						})
+
+						return ctrl.Result{RequeueAfter: time.Minute}, nil
+					}
+
+					err = nginxpmClient.DeleteAccessListContext(ctx, int(*acl.Status.Id))
+					if err != nil {
+						log.Error(err, "Failed to delete access list from remote NPM")
+					}
+				default:
+					log.Info("Leaving access list in remote NPM", "deletionPolicy", deletionPolicy)
 				}
 			}
 
@@ -161,6 +227,12 @@ func (r *AccessListReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
+	// Pin the token found by the fallback lookup, the resource keeps using it from now on
+	if err := controller.PinTokenReference(ctx, r.Client, acl, &acl.Spec.Token, tokenRef); err != nil {
+		log.Error(err, "Failed to pin the token reference of AccessList")
+		return ctrl.Result{}, err
+	}
+
 	// Create or update access list
 	err = r.createOrUpdateAccessList(ctx, req, acl, nginxpmClient)
 	if err != nil {
@@ -175,11 +247,22 @@ func (r *AccessListReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			})
 		})
 
+		return controller.ResultForNginxPMError(nginxpmClient, err)
+	}
+
+	// Track the ProxyHosts referencing the access list
+	references, err := r.referencingProxyHosts(ctx, acl)
+	if err != nil {
+		log.Error(err, "Failed to list the ProxyHosts referencing the access list")
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
 	// Set the status as True when the client can be created
 	controller.UpdateStatus(ctx, r.Client, acl, req.NamespacedName, func() {
+		acl.Status.TokenRef = tokenRef
+		acl.Status.ProxyHosts = references
+		setInUseCondition(&acl.Status.Conditions, acl.Generation, references)
+		controller.SetSuspendedCondition(&acl.Status.Conditions, acl.Generation, false)
 		meta.SetStatusCondition(&acl.Status.Conditions, metav1.Condition{
 			Status:             metav1.ConditionTrue,
 			Type:               controller.ConditionTypeReady,
@@ -189,7 +272,7 @@ func (r *AccessListReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		})
 	})
 
-	return ctrl.Result{}, nil
+	return controller.ResyncResult(), nil
 }
 
 func (r *AccessListReconciler) createOrUpdateAccessList(ctx context.Context, req ctrl.Request, acl *nginxpmoperatoriov1.AccessList, nginxpmClient *nginxpm.Client) error {
@@ -198,8 +281,13 @@ func (r *AccessListReconciler) createOrUpdateAccessList(ctx context.Context, req
 	var accessList *nginxpm.AccessList
 	var err error
 
+	bound := acl.Status.Bound
+
+	// Preserve existing initial configuration
+	capturedInitialConfig := acl.Status.InitialConfiguration
+
 	if acl.Status.Id != nil {
-		accessList, err = nginxpmClient.FindAccessListByID(*acl.Status.Id)
+		accessList, err = nginxpmClient.FindAccessListByIDContext(ctx, *acl.Status.Id)
 		if err != nil {
 			r.Recorder.Event(
 				acl, "Warning", "FindAccessListByID",
@@ -212,32 +300,88 @@ func (r *AccessListReconciler) createOrUpdateAccessList(ctx context.Context, req
 		}
 	}
 
-	authorizations := make([]nginxpm.AccessListItem, len(acl.Spec.Authorizations))
-	for i, authorization := range acl.Spec.Authorizations {
-		authorizations[i] = nginxpm.AccessListItem{
-			Username: authorization.Username,
-			Password: authorization.Password,
+	// Bind the access list named after the resource, or spec.listName
+	if accessList == nil && acl.Spec.BindExisting {
+		accessList, err = nginxpmClient.FindAccessListByNameContext(ctx, listName(acl))
+		if err != nil {
+			r.Recorder.Event(
+				acl, "Warning", "FindAccessListByName",
+				fmt.Sprintf("Failed to find access list by name, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to find access list by name")
+			return err
 		}
-	}
 
-	clients := make([]nginxpm.AccessListClient, len(acl.Spec.Clients))
-	for i, client := range acl.Spec.Clients {
-		clients[i] = nginxpm.AccessListClient{
-			Address:   client.Address,
-			Directive: client.Directive,
+		if accessList != nil {
+			bound = true
+
+			// Capture initial configuration if we haven't already
+			if ShouldCaptureInitialConfig(acl, accessList) {
+				capturedInitialConfig = CaptureInitialConfiguration(accessList)
+				log.Info("Captured initial configuration for bound access list", "accessListId", accessList.ID)
+			}
 		}
 	}
 
+	authorizations, err := r.makeAuthorizations(ctx, acl)
+	if err != nil {
+		r.Recorder.Event(
+			acl, "Warning", "MakeAuthorizations",
+			fmt.Sprintf("Failed to read the authorizations, ResourceName: %s, Namespace: %s, err: %s",
+				req.Name, req.Namespace, err.Error()),
+		)
+
+		log.Error(err, "Failed to read the authorizations")
+		return err
+	}
+
+	clients, err := r.makeClients(ctx, acl)
+	if err != nil {
+		r.Recorder.Event(
+			acl, "Warning", "MakeClients",
+			fmt.Sprintf("Failed to read the clients, ResourceName: %s, Namespace: %s, err: %s",
+				req.Name, req.Namespace, err.Error()),
+		)
+
+		log.Error(err, "Failed to read the clients")
+		return err
+	}
+
 	input := nginxpm.AccessListRequestInput{
-		Name:       acl.Name,
+		Name:       listName(acl),
 		SatisfyAny: acl.Spec.SatisfyAny,
 		PassAuth:   acl.Spec.PassAuth,
 		Items:      authorizations,
 		Clients:    clients,
 	}
 
-	if accessList == nil {
-		accessList, err = nginxpmClient.CreateAccessList(input)
+	// Look for changes made outside of the operator, the access list is updated
+	// when the configuration built from the spec is not the one applied last
+	configHash := controller.HashAccessListConfig(input, acl.Generation, controller.SecretVersions(ctx, r, acl.Namespace, secretNames(acl)))
+	driftPolicy := controller.DriftPolicy(acl.Spec.DriftPolicy)
+
+	drift, upToDate := controller.CheckDrift(driftPolicy, accessList != nil, acl.Status.AppliedConfigHash, configHash, func() []string {
+		return DiffAccessList(accessList, input)
+	})
+
+	if len(drift) > 0 {
+		log.Info("AccessList drifted from its spec", "drift", drift, "driftPolicy", driftPolicy)
+
+		if controller.DriftChanged(acl.Status.Conditions, driftPolicy, drift) {
+			r.Recorder.Event(
+				acl, "Warning", "DriftDetected",
+				fmt.Sprintf("Access list changed outside of the operator, ResourceName: %s, Namespace: %s, drift: %s",
+					req.Name, req.Namespace, strings.Join(drift, "; ")),
+			)
+		}
+	}
+
+	if upToDate {
+		log.Info("AccessList is up to date")
+	} else if accessList == nil {
+		accessList, err = nginxpmClient.CreateAccessListContext(ctx, input)
 		if err != nil {
 			r.Recorder.Event(
 				acl, "Warning", "CreateAccessList",
@@ -251,7 +395,7 @@ func (r *AccessListReconciler) createOrUpdateAccessList(ctx context.Context, req
 
 		log.Info("AccessList created successfully")
 	} else {
-		accessList, err = nginxpmClient.UpdateAccessList(accessList.ID, input)
+		accessList, err = nginxpmClient.UpdateAccessListContext(ctx, accessList.ID, input)
 		if err != nil {
 			r.Recorder.Event(
 				acl, "Warning", "UpdateAccessList",
@@ -269,6 +413,10 @@ func (r *AccessListReconciler) createOrUpdateAccessList(ctx context.Context, req
 	return controller.UpdateStatus(ctx, r.Client, acl, req.NamespacedName, func() {
 		acl.Status.Id = &accessList.ID
 		acl.Status.ProxyHostCount = accessList.ProxyHostCount
+		acl.Status.AppliedConfigHash = configHash
+		acl.Status.Bound = bound
+		acl.Status.InitialConfiguration = capturedInitialConfig
+		controller.SetDriftedCondition(&acl.Status.Conditions, acl.Generation, driftPolicy, drift)
 	})
 }
 
@@ -299,6 +447,22 @@ func (r *AccessListReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// Add the Secrets to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &nginxpmoperatoriov1.AccessList{}, ACL_SECRET_FIELD, func(rawObj client.Object) []string {
+		return secretNames(rawObj.(*nginxpmoperatoriov1.AccessList))
+	}); err != nil {
+		return err
+	}
+
+	// Add the sources of the clients to the indexer
+	for _, field := range []string{ACL_NODES_FIELD, ACL_CONFIGMAP_FIELD, ACL_SERVICE_FIELD} {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &nginxpmoperatoriov1.AccessList{}, field, func(rawObj client.Object) []string {
+			return sourceIndexes(rawObj.(*nginxpmoperatoriov1.AccessList), field)
+		}); err != nil {
+			return err
+		}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&nginxpmoperatoriov1.AccessList{}).
 		Owns(&nginxpmoperatoriov1.Token{}).
@@ -307,6 +471,35 @@ func (r *AccessListReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(ACL_TOKEN_FIELD)),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&nginxpmoperatoriov1.ClusterToken{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(ACL_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&nginxpmoperatoriov1.ProxyHost{},
+			proxyHostReferenceHandler(),
+		).
+		Watches(
+			&corev1.Node{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSource(ACL_NODES_FIELD)),
+			builder.WithPredicates(nodeAddressesChangedPredicate()),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSource(ACL_CONFIGMAP_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSource(ACL_SERVICE_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Named("accesslist").
 		Complete(r)
 }
@@ -341,3 +534,86 @@ func (r *AccessListReconciler) findObjectsForMap(field string) func(ctx context.
 		return requests
 	}
 }
+
+// findObjectsForSecret enqueues the access lists of the namespace of the Secret reading their passwords from it
+func (r *AccessListReconciler) findObjectsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	attachedObjects := &nginxpmoperatoriov1.AccessListList{}
+
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(ACL_SECRET_FIELD, secret.GetName()),
+		Namespace:     secret.GetNamespace(),
+	}
+
+	err := r.List(ctx, attachedObjects, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(attachedObjects.Items))
+	for i, item := range attachedObjects.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+	}
+
+	return requests
+}
+
+// findObjectsForSource enqueues the access lists reading their clients from the node, configmap or service
+func (r *AccessListReconciler) findObjectsForSource(field string) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, object client.Object) []reconcile.Request {
+		listOps := &client.ListOptions{}
+
+		switch field {
+		case ACL_NODES_FIELD:
+			listOps.FieldSelector = fields.OneTermEqualSelector(field, ACL_NODES_SOURCE)
+		case ACL_SERVICE_FIELD:
+			listOps.FieldSelector = fields.OneTermEqualSelector(field, client.ObjectKeyFromObject(object).String())
+		default:
+			listOps.FieldSelector = fields.OneTermEqualSelector(field, object.GetName())
+			listOps.Namespace = object.GetNamespace()
+		}
+
+		attachedObjects := &nginxpmoperatoriov1.AccessListList{}
+		if err := r.List(ctx, attachedObjects, listOps); err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, len(attachedObjects.Items))
+		for i, item := range attachedObjects.Items {
+			requests[i] = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			}
+		}
+
+		return requests
+	}
+}
+
+// nodeAddressesChangedPredicate ignores the frequent status updates of the nodes
+// which change neither their labels, addresses nor pod CIDRs
+func nodeAddressesChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+
+			return !equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+				!equality.Semantic.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses) ||
+				!equality.Semantic.DeepEqual(oldNode.Spec.PodCIDRs, newNode.Spec.PodCIDRs) ||
+				oldNode.Spec.PodCIDR != newNode.Spec.PodCIDR
+		},
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslist
+
+import (
+	"context"
+	"fmt"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// secretNames lists the Secrets referenced by the access list, they are indexed under ACL_SECRET_FIELD
+func secretNames(acl *nginxpmoperatoriov1.AccessList) []string {
+	var names []string
+
+	for _, authorization := range acl.Spec.Authorizations {
+		if authorization.PasswordSecretRef != nil {
+			names = append(names, authorization.PasswordSecretRef.Name)
+		}
+	}
+
+	if acl.Spec.AuthorizationsSecretRef != nil {
+		names = append(names, acl.Spec.AuthorizationsSecretRef.Name)
+	}
+
+	return names
+}
+
+// makeAuthorizations builds the items of the access list from the spec, with the passwords read from the
+// referenced Secrets, followed by the users of the authorizations Secret
+func (r *AccessListReconciler) makeAuthorizations(ctx context.Context, acl *nginxpmoperatoriov1.AccessList) ([]nginxpm.AccessListItem, error) {
+	authorizations := make([]nginxpm.AccessListItem, len(acl.Spec.Authorizations))
+
+	for i, authorization := range acl.Spec.Authorizations {
+		password := authorization.Password
+
+		if authorization.PasswordSecretRef != nil {
+			value, err := controller.GetSecretKeyValue(ctx, r, acl.Namespace, authorization.PasswordSecretRef)
+			if err != nil {
+				return nil, fmt.Errorf("password of user %s: %w", authorization.Username, err)
+			}
+			password = string(value)
+		}
+
+		authorizations[i] = nginxpm.AccessListItem{
+			Username: authorization.Username,
+			Password: password,
+		}
+	}
+
+	if acl.Spec.AuthorizationsSecretRef != nil {
+		value, err := controller.GetSecretKeyValue(ctx, r, acl.Namespace, acl.Spec.AuthorizationsSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("authorizations: %w", err)
+		}
+
+		items, err := controller.ParseAuthorizations(value)
+		if err != nil {
+			return nil, fmt.Errorf("authorizations of secret %s: %w", acl.Spec.AuthorizationsSecretRef.Name, err)
+		}
+
+		authorizations = append(authorizations, items...)
+	}
+
+	return authorizations, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslist
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// ACL_NODES_SOURCE is the value indexed under ACL_NODES_FIELD for the access lists reading nodes
+const ACL_NODES_SOURCE = "nodes"
+
+// sourceIndexes lists the values indexed under ACL_NODES_FIELD, ACL_CONFIGMAP_FIELD and ACL_SERVICE_FIELD
+// for the client sources of the access list, services are indexed as "namespace/name"
+func sourceIndexes(acl *nginxpmoperatoriov1.AccessList, field string) []string {
+	var values []string
+
+	for _, client := range acl.Spec.Clients {
+		source := client.Source
+		if source == nil {
+			continue
+		}
+
+		switch {
+		case field == ACL_NODES_FIELD && (source.Nodes != nil || source.PodCIDR != nil):
+			values = append(values, ACL_NODES_SOURCE)
+		case field == ACL_CONFIGMAP_FIELD && source.ConfigMapKeyRef != nil:
+			values = append(values, source.ConfigMapKeyRef.Name)
+		case field == ACL_SERVICE_FIELD && source.Service != nil:
+			values = append(values, serviceKey(acl, source.Service).String())
+		}
+	}
+
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+func serviceKey(acl *nginxpmoperatoriov1.AccessList, service *nginxpmoperatoriov1.AccessListServiceSource) types.NamespacedName {
+	namespace := acl.Namespace
+	if service.Namespace != nil {
+		namespace = *service.Namespace
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: service.Name}
+}
+
+// makeClients builds the clients of the access list from the spec, a client with a source
+// is expanded into one client per address read from it
+func (r *AccessListReconciler) makeClients(ctx context.Context, acl *nginxpmoperatoriov1.AccessList) ([]nginxpm.AccessListClient, error) {
+	var clients []nginxpm.AccessListClient
+
+	for i, client := range acl.Spec.Clients {
+		if client.Source == nil {
+			clients = append(clients, nginxpm.AccessListClient{Address: client.Address, Directive: client.Directive})
+			continue
+		}
+
+		addresses, err := r.sourceAddresses(ctx, acl, client.Source)
+		if err != nil {
+			return nil, fmt.Errorf("source of client %d: %w", i, err)
+		}
+
+		for _, address := range addresses {
+			clients = append(clients, nginxpm.AccessListClient{Address: address, Directive: client.Directive})
+		}
+	}
+
+	return clients, nil
+}
+
+// sourceAddresses reads the addresses of a client source, without duplicates and in a stable order
+func (r *AccessListReconciler) sourceAddresses(ctx context.Context, acl *nginxpmoperatoriov1.AccessList, source *nginxpmoperatoriov1.AccessListClientSource) ([]string, error) {
+	var addresses []string
+
+	switch {
+	case source.Nodes != nil:
+		nodes, err := r.listNodes(ctx, source.Nodes.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		addressTypes := source.Nodes.AddressTypes
+		if len(addressTypes) == 0 {
+			addressTypes = []nginxpmoperatoriov1.NodeAddressType{nginxpmoperatoriov1.NodeAddressType(corev1.NodeInternalIP)}
+		}
+
+		for _, node := range nodes {
+			for _, address := range node.Status.Addresses {
+				if slices.Contains(addressTypes, nginxpmoperatoriov1.NodeAddressType(address.Type)) {
+					addresses = append(addresses, address.Address)
+				}
+			}
+		}
+
+	case source.PodCIDR != nil:
+		nodes, err := r.listNodes(ctx, source.PodCIDR.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range nodes {
+			if len(node.Spec.PodCIDRs) == 0 && node.Spec.PodCIDR != "" {
+				addresses = append(addresses, node.Spec.PodCIDR)
+			}
+			addresses = append(addresses, node.Spec.PodCIDRs...)
+		}
+
+	case source.ConfigMapKeyRef != nil:
+		selector := source.ConfigMapKeyRef
+		optional := selector.Optional != nil && *selector.Optional
+
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: acl.Namespace, Name: selector.Name}, configMap); err != nil {
+			if optional && client.IgnoreNotFound(err) == nil {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("get configmap %s: %w", selector.Name, err)
+		}
+
+		value, ok := configMap.Data[selector.Key]
+		if !ok && !optional {
+			return nil, fmt.Errorf("key %s not found in configmap %s", selector.Key, selector.Name)
+		}
+
+		parsed, err := parseAddresses([]byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("addresses of configmap %s: %w", selector.Name, err)
+		}
+		addresses = parsed
+
+	case source.Service != nil:
+		key := serviceKey(acl, source.Service)
+
+		service := &corev1.Service{}
+		if err := r.Get(ctx, key, service); err != nil {
+			return nil, fmt.Errorf("get service %s: %w", key, err)
+		}
+
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("no source is set")
+	}
+
+	slices.Sort(addresses)
+	return slices.Compact(addresses), nil
+}
+
+func (r *AccessListReconciler) listNodes(ctx context.Context, selector *metav1.LabelSelector) ([]corev1.Node, error) {
+	listOps := &client.ListOptions{}
+
+	if selector != nil {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("node selector: %w", err)
+		}
+		listOps.LabelSelector = labelSelector
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, listOps); err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	return nodes.Items, nil
+}
+
+// parseAddresses reads one IP address or CIDR per line
+func parseAddresses(data []byte) ([]string, error) {
+	var addresses []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if net.ParseIP(text) == nil {
+			if _, _, err := net.ParseCIDR(text); err != nil {
+				return nil, fmt.Errorf("line %d: %s is not an IP address or a CIDR", line, text)
+			}
+		}
+
+		addresses = append(addresses, text)
+	}
+
+	return addresses, scanner.Err()
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslist
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+func TestMakeClients(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Labels: map[string]string{"role": "worker"}},
+		Spec:       corev1.NodeSpec{PodCIDRs: []string{"10.244.1.0/24"}},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "192.168.1.10"},
+			{Type: corev1.NodeExternalIP, Address: "203.0.113.10"},
+			{Type: corev1.NodeHostName, Address: "worker"},
+		}},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "office", Namespace: "default"},
+		Data:       map[string]string{"egress": "# office\n198.51.100.0/28\n\n198.51.100.20\n"},
+	}
+
+	r := &AccessListReconciler{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(node, configMap).Build()}
+
+	acl := &nginxpmoperatoriov1.AccessList{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: nginxpmoperatoriov1.AccessListSpec{
+			Clients: []nginxpmoperatoriov1.AccessListClient{
+				{Address: "10.0.0.1", Directive: "deny"},
+				{Directive: "allow", Source: &nginxpmoperatoriov1.AccessListClientSource{
+					Nodes: &nginxpmoperatoriov1.AccessListNodesSource{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+					},
+				}},
+				{Directive: "allow", Source: &nginxpmoperatoriov1.AccessListClientSource{
+					PodCIDR: &nginxpmoperatoriov1.AccessListPodCIDRSource{},
+				}},
+				{Directive: "allow", Source: &nginxpmoperatoriov1.AccessListClientSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "office"},
+						Key:                  "egress",
+					},
+				}},
+			},
+		},
+	}
+
+	clients, err := r.makeClients(context.Background(), acl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []nginxpm.AccessListClient{
+		{Address: "10.0.0.1", Directive: "deny"},
+		{Address: "192.168.1.10", Directive: "allow"},
+		{Address: "10.244.1.0/24", Directive: "allow"},
+		{Address: "198.51.100.0/28", Directive: "allow"},
+		{Address: "198.51.100.20", Directive: "allow"},
+	}
+	if !slices.Equal(clients, expected) {
+		t.Errorf("Expected %v, got %v", expected, clients)
+	}
+
+	configMap.Data["egress"] = "not-an-address\n"
+	if err := r.Update(context.Background(), configMap); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.makeClients(context.Background(), acl); err == nil {
+		t.Error("Expected an error for an invalid address in the configmap")
+	}
+}
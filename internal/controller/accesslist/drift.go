@@ -0,0 +1,60 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslist
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// DiffAccessList compares the live access list with the input built from the spec,
+// it returns one "field: expected X, found Y" entry per field which differs.
+// Passwords are not returned by NPM, so only the usernames of the items are compared.
+func DiffAccessList(live *nginxpm.AccessList, input nginxpm.AccessListRequestInput) []string {
+	var diff []string
+
+	compare := func(field string, expected, found interface{}) {
+		if expected != found {
+			diff = append(diff, fmt.Sprintf("%s: expected %v, found %v", field, expected, found))
+		}
+	}
+
+	compare("name", input.Name, live.Name)
+	compare("satisfy_any", input.SatisfyAny, live.SatisfyAny)
+	compare("pass_auth", input.PassAuth, live.PassAuth)
+
+	usernames := func(items []nginxpm.AccessListItem) []string {
+		names := make([]string, len(items))
+		for i, item := range items {
+			names[i] = item.Username
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	if expected, found := usernames(input.Items), usernames(live.Items); !slices.Equal(expected, found) {
+		diff = append(diff, fmt.Sprintf("items: expected %v, found %v", expected, found))
+	}
+
+	if !slices.Equal(input.Clients, live.Clients) {
+		diff = append(diff, fmt.Sprintf("clients: expected %v, found %v", input.Clients, live.Clients))
+	}
+
+	return diff
+}
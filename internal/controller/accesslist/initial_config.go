@@ -0,0 +1,99 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslist
+
+import (
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// listName returns the name of the access list in NPM
+func listName(acl *nginxpmoperatoriov1.AccessList) string {
+	if acl.Spec.ListName != "" {
+		return acl.Spec.ListName
+	}
+
+	return acl.Name
+}
+
+// CaptureInitialConfiguration captures the initial state of an existing NPM access list
+// before any modifications are made by the operator
+func CaptureInitialConfiguration(accessList *nginxpm.AccessList) *nginxpmoperatoriov1.AccessListInitialConfiguration {
+	if accessList == nil {
+		return nil
+	}
+
+	config := &nginxpmoperatoriov1.AccessListInitialConfiguration{
+		SatisfyAny: accessList.SatisfyAny,
+		PassAuth:   accessList.PassAuth,
+	}
+
+	for _, item := range accessList.Items {
+		config.Usernames = append(config.Usernames, item.Username)
+	}
+
+	for _, client := range accessList.Clients {
+		config.Clients = append(config.Clients, nginxpmoperatoriov1.AccessListClient{
+			Address:   client.Address,
+			Directive: client.Directive,
+		})
+	}
+
+	return config
+}
+
+// BuildRestorationInput creates an AccessListRequestInput from the stored initial configuration
+// to restore the original settings when a bound resource is deleted. The items are sent without
+// password, so that NPM keeps the passwords of the users which are still in the list.
+func BuildRestorationInput(name string, config *nginxpmoperatoriov1.AccessListInitialConfiguration) *nginxpm.AccessListRequestInput {
+	if config == nil {
+		return nil
+	}
+
+	input := &nginxpm.AccessListRequestInput{
+		Name:       name,
+		SatisfyAny: config.SatisfyAny,
+		PassAuth:   config.PassAuth,
+		Items:      make([]nginxpm.AccessListItem, len(config.Usernames)),
+		Clients:    make([]nginxpm.AccessListClient, len(config.Clients)),
+	}
+
+	for i, username := range config.Usernames {
+		input.Items[i] = nginxpm.AccessListItem{Username: username}
+	}
+
+	for i, client := range config.Clients {
+		input.Clients[i] = nginxpm.AccessListClient{Address: client.Address, Directive: client.Directive}
+	}
+
+	return input
+}
+
+// ShouldCaptureInitialConfig determines if we should capture the initial configuration
+// This happens when binding to an existing access list for the first time
+func ShouldCaptureInitialConfig(acl *nginxpmoperatoriov1.AccessList, accessList *nginxpm.AccessList) bool {
+	return acl.Spec.BindExisting &&
+		accessList != nil &&
+		acl.Status.InitialConfiguration == nil
+}
+
+// ShouldRestoreInitialConfig determines if we should restore the initial configuration
+// This happens when deleting a bound resource that has stored initial configuration
+func ShouldRestoreInitialConfig(acl *nginxpmoperatoriov1.AccessList) bool {
+	return acl.Status.Bound &&
+		acl.Status.InitialConfiguration != nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslist
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/proxyhost"
+)
+
+// referencingProxyHosts lists the ProxyHosts referencing the access list by name, as "namespace/name"
+func (r *AccessListReconciler) referencingProxyHosts(ctx context.Context, acl *nginxpmoperatoriov1.AccessList) ([]string, error) {
+	proxyHosts := &nginxpmoperatoriov1.ProxyHostList{}
+
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(proxyhost.PH_ACCESS_LIST_FIELD, acl.Name),
+	}
+
+	if err := r.List(ctx, proxyHosts, listOps); err != nil {
+		return nil, fmt.Errorf("list proxy hosts referencing the access list: %w", err)
+	}
+
+	var references []string
+	for _, ph := range proxyHosts.Items {
+		// The access list is looked for in the namespace of the proxy host when none is given
+		namespace := ph.Namespace
+		if ph.Spec.AccessList.Namespace != nil {
+			namespace = *ph.Spec.AccessList.Namespace
+		}
+
+		if namespace == acl.Namespace {
+			references = append(references, ph.Namespace+"/"+ph.Name)
+		}
+	}
+
+	slices.Sort(references)
+	return references, nil
+}
+
+// setInUseCondition records whether the access list is referenced by ProxyHosts
+func setInUseCondition(conditions *[]metav1.Condition, generation int64, references []string) {
+	condition := metav1.Condition{
+		Status:             metav1.ConditionFalse,
+		Type:               controller.ConditionTypeInUse,
+		Reason:             "NotReferenced",
+		Message:            "No ProxyHost references the access list",
+		ObservedGeneration: generation,
+	}
+
+	if len(references) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ReferencedByProxyHosts"
+		condition.Message = "Referenced by " + strings.Join(references, ", ")
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// proxyHostReferenceHandler enqueues the access lists referenced by a ProxyHost,
+// before and after an update so that a dropped reference is noticed too
+func proxyHostReferenceHandler() handler.EventHandler {
+	enqueue := func(object client.Object, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		ph, ok := object.(*nginxpmoperatoriov1.ProxyHost)
+		if !ok || ph.Spec.AccessList == nil || ph.Spec.AccessList.Name == "" {
+			return
+		}
+
+		namespace := ph.Namespace
+		if ph.Spec.AccessList.Namespace != nil {
+			namespace = *ph.Spec.AccessList.Namespace
+		}
+
+		queue.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: ph.Spec.AccessList.Name}})
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(e.Object, queue)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(e.ObjectOld, queue)
+			enqueue(e.ObjectNew, queue)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(e.Object, queue)
+		},
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accesslist
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/proxyhost"
+)
+
+func TestReferencingProxyHosts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = nginxpmoperatoriov1.AddToScheme(scheme)
+
+	defaultNamespace, otherNamespace := "default", "other"
+	proxyHost := func(namespace, name string, accessListNamespace *string) *nginxpmoperatoriov1.ProxyHost {
+		return &nginxpmoperatoriov1.ProxyHost{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: nginxpmoperatoriov1.ProxyHostSpec{
+				AccessList: &nginxpmoperatoriov1.ProxyHostAccessList{Name: "admin", Namespace: accessListNamespace},
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			proxyHost("default", "app", nil),
+			proxyHost("other", "dashboard", nil),
+			proxyHost("other", "api", &defaultNamespace),
+			proxyHost("default", "legacy", &otherNamespace),
+		).
+		WithIndex(&nginxpmoperatoriov1.ProxyHost{}, proxyhost.PH_ACCESS_LIST_FIELD, func(object client.Object) []string {
+			return []string{object.(*nginxpmoperatoriov1.ProxyHost).Spec.AccessList.Name}
+		}).
+		Build()
+
+	r := &AccessListReconciler{Client: c}
+	acl := &nginxpmoperatoriov1.AccessList{ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "default"}}
+
+	references, err := r.referencingProxyHosts(context.Background(), acl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := []string{"default/app", "other/api"}; !slices.Equal(references, expected) {
+		t.Errorf("Expected %v, got %v", expected, references)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// GetSecretKeyValue reads the key of the selected Secret of the namespace,
+// an optional Secret or key which is missing gives an empty value
+func GetSecretKeyValue(ctx context.Context, r client.Reader, namespace string, selector *corev1.SecretKeySelector) ([]byte, error) {
+	optional := selector.Optional != nil && *selector.Optional
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, secret); err != nil {
+		if optional && client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get secret %s: %w", selector.Name, err)
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok && !optional {
+		return nil, fmt.Errorf("key %s not found in secret %s", selector.Key, selector.Name)
+	}
+
+	return value, nil
+}
+
+// ParseAuthorizations reads one "username:password" user per line, as in an htpasswd file with clear passwords
+func ParseAuthorizations(data []byte) ([]nginxpm.AccessListItem, error) {
+	var items []nginxpm.AccessListItem
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		username, password, found := strings.Cut(text, ":")
+		if !found || username == "" || password == "" {
+			return nil, fmt.Errorf("line %d: expected username:password", line)
+		}
+
+		items = append(items, nginxpm.AccessListItem{Username: username, Password: password})
+	}
+
+	return items, scanner.Err()
+}
+
+// SecretVersions returns the resource versions of the named Secrets of the namespace, a missing Secret has none
+func SecretVersions(ctx context.Context, r client.Reader, namespace string, names []string) []string {
+	versions := make([]string, len(names))
+
+	for i, name := range names {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err == nil {
+			versions[i] = name + "@" + secret.ResourceVersion
+		}
+	}
+
+	return versions
+}
+
+// HashAccessListConfig returns the hash of the configuration of an access list kept in the status of its resource.
+// The passwords of the users are left out, an unsalted hash readable with the resource would let them be
+// recovered offline. The generation of the resource and the versions of the Secrets holding them stand for them.
+func HashAccessListConfig(input nginxpm.AccessListRequestInput, generation int64, secretVersions []string) string {
+	items := make([]nginxpm.AccessListItem, len(input.Items))
+	for i, item := range input.Items {
+		items[i] = nginxpm.AccessListItem{Username: item.Username}
+	}
+	input.Items = items
+
+	return HashConfig(struct {
+		Input          nginxpm.AccessListRequestInput
+		Generation     int64
+		SecretVersions []string
+	}{input, generation, secretVersions})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+func TestParseAuthorizations(t *testing.T) {
+	items, err := ParseAuthorizations([]byte("# users\nadmin:s3cr3t:with:colons\n\n  viewer:viewer  \n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(items) != 2 || items[0].Username != "admin" || items[0].Password != "s3cr3t:with:colons" || items[1].Username != "viewer" {
+		t.Errorf("Unexpected items: %v", items)
+	}
+
+	if _, err := ParseAuthorizations([]byte("admin\n")); err == nil {
+		t.Error("Expected an error for a line without password")
+	}
+}
+
+func TestHashAccessListConfig(t *testing.T) {
+	input := nginxpm.AccessListRequestInput{Name: "admin", Items: []nginxpm.AccessListItem{{Username: "admin", Password: "s3cr3t"}}}
+	hash := HashAccessListConfig(input, 1, []string{"users@1"})
+
+	// The password must not be part of the hash
+	input.Items = []nginxpm.AccessListItem{{Username: "admin", Password: "other"}}
+	if HashAccessListConfig(input, 1, []string{"users@1"}) != hash {
+		t.Error("Expected the hash not to depend on the passwords")
+	}
+
+	if input.Items[0].Password != "other" {
+		t.Error("Expected the input to be left as is")
+	}
+
+	if HashAccessListConfig(input, 1, []string{"users@2"}) == hash {
+		t.Error("Expected a new version of the secret to change the hash")
+	}
+
+	if HashAccessListConfig(input, 2, []string{"users@1"}) == hash {
+		t.Error("Expected a new generation to change the hash")
+	}
+}
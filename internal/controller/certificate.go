@@ -13,6 +13,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// DEFAULT_EMAIL is the Let's Encrypt contact used when none is provided
+const DEFAULT_EMAIL = "support@nginxpm-operator.io"
+
 type RetrieveCertificateOption struct {
 	Cxt           context.Context
 	Req           ctrl.Request
@@ -82,7 +85,7 @@ func getLetsEncryptCertificateByReference(ctx context.Context, r client.Reader,
 		return nil, fmt.Errorf("no certificate ID is provided, please check the LetsEncryptCertificate resource")
 	}
 
-	certificate, err := nginxpmClient.FindCertificateByID(*lec.Status.Id)
+	certificate, err := nginxpmClient.FindCertificateByIDContext(ctx, *lec.Status.Id)
 	if err != nil {
 		log.Error(err, "Failed to find certificate by ID")
 		return nil, err
@@ -113,7 +116,7 @@ func getCustomCertificateByReference(ctx context.Context, r client.Reader, req c
 		return nil, fmt.Errorf("no certificate ID is provided, please check the CustomCertificate resource")
 	}
 
-	certificate, err := nginxpmClient.FindCertificateByID(*customCert.Status.Id)
+	certificate, err := nginxpmClient.FindCertificateByIDContext(ctx, *customCert.Status.Id)
 	if err != nil {
 		log.Error(err, "Failed to find certificate by ID")
 		return nil, err
@@ -126,7 +129,7 @@ func getCustomCertificateByReference(ctx context.Context, r client.Reader, req c
 func getCertificateFromID(ctx context.Context, id int, nginxpmClient *nginxpm.Client) (*nginxpm.Certificate, error) {
 	log := log.FromContext(ctx)
 
-	certificate, err := nginxpmClient.FindCertificateByID(id)
+	certificate, err := nginxpmClient.FindCertificateByIDContext(ctx, id)
 	if err != nil {
 		log.Error(err, "Failed to find certificate by ID")
 		return nil, err
@@ -134,3 +137,49 @@ func getCertificateFromID(ctx context.Context, id int, nginxpmClient *nginxpm.Cl
 
 	return certificate, nil
 }
+
+// FindOrCreateCertificate finds a certificate matching the given domains.
+// If certificate is not found, a new one is requested from Let's Encrypt
+func FindOrCreateCertificate(ctx context.Context, nginxpmClient *nginxpm.Client, domains []string, email *string) (*nginxpm.Certificate, error) {
+	log := log.FromContext(ctx)
+
+	letsEncryptEmail := DEFAULT_EMAIL
+	if email != nil && *email != "" {
+		letsEncryptEmail = *email
+	}
+
+	certificate, err := nginxpmClient.FindCertificateByDomainContext(ctx, domains)
+	if err != nil {
+		log.Error(err, "[autoCertificateRequest] Failed to find certificate by domain")
+		return nil, err
+	}
+
+	if certificate != nil {
+		log.Info("[autoCertificateRequest] Certificate found, applying to host")
+		return certificate, nil
+	}
+
+	log.Info("[autoCertificateRequest] Certificate not found, creating new certificate...")
+	lecCertificate, err := nginxpmClient.CreateLetEncryptCertificateContext(ctx, nginxpm.CreateLetEncryptCertificateRequest{
+		DomainNames: domains,
+		Meta: nginxpm.CreateLetEncryptCertificateRequestMeta{
+			DNSChallenge:     false,
+			LetsEncryptAgree: true,
+			LetsEncryptEmail: letsEncryptEmail,
+		},
+	})
+	if err != nil {
+		log.Error(err, "[autoCertificateRequest] Failed to create certificate")
+		return nil, err
+	}
+
+	return &nginxpm.Certificate{
+		ID:          lecCertificate.ID,
+		CreatedOn:   lecCertificate.CreatedOn,
+		ModifiedOn:  lecCertificate.ModifiedOn,
+		Provider:    lecCertificate.Provider,
+		NiceName:    lecCertificate.NiceName,
+		DomainNames: lecCertificate.DomainNames,
+		ExpiresOn:   lecCertificate.ExpiresOn,
+	}, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+	"github.com/paradoxe35/nginxpm-operator/pkg/util"
+)
+
+// DEFAULT_CLIENT_ACCESS_CHECK_TTL is how long the token access check of a pooled client is trusted
+const DEFAULT_CLIENT_ACCESS_CHECK_TTL = time.Minute
+
+// ClientPool is the pool of the clients returned by InitNginxPMClient
+var ClientPool = NewNginxPMClientPool(DEFAULT_CLIENT_ACCESS_CHECK_TTL)
+
+// NginxPMClientPool shares the Nginx Proxy Manager clients of Token and ClusterToken resources
+// between the reconciles of all controllers. A client is kept as long as the resourceVersion of
+// its token resource does not change, which happens every time the token is rotated.
+type NginxPMClientPool struct {
+	// AccessCheckTTL is how long a successful token access check of a client is trusted
+	AccessCheckTTL time.Duration
+
+	mu         sync.Mutex
+	httpClient *http.Client
+	entries    map[types.UID]pooledClient
+}
+
+type pooledClient struct {
+	resourceVersion string
+	client          *nginxpm.Client
+	checkedAt       time.Time
+}
+
+// NewNginxPMClientPool creates a pool whose clients share a single http client
+func NewNginxPMClientPool(accessCheckTTL time.Duration) *NginxPMClientPool {
+	return &NginxPMClientPool{
+		AccessCheckTTL: accessCheckTTL,
+		httpClient:     util.NewHttpClient(),
+		entries:        map[types.UID]pooledClient{},
+	}
+}
+
+// Get returns the pooled client of the token resource, build is only called to create the client
+// when there is none for the current resourceVersion of the resource.
+// The token access is checked again once the AccessCheckTTL of the last check has elapsed.
+func (p *NginxPMClientPool) Get(ctx context.Context, token client.Object, build func(httpClient *http.Client) (*nginxpm.Client, error)) (*nginxpm.Client, error) {
+	p.mu.Lock()
+	entry, ok := p.entries[token.GetUID()]
+	p.mu.Unlock()
+
+	if !ok || entry.resourceVersion != token.GetResourceVersion() {
+		nginxpmClient, err := build(p.httpClient)
+		if err != nil {
+			return nil, err
+		}
+
+		entry = pooledClient{resourceVersion: token.GetResourceVersion(), client: nginxpmClient}
+	}
+
+	if time.Since(entry.checkedAt) >= p.AccessCheckTTL {
+		if err := entry.client.CheckTokenAccessContext(ctx); err != nil {
+			p.Invalidate(token.GetUID())
+			return nil, err
+		}
+
+		entry.checkedAt = time.Now()
+	}
+
+	p.mu.Lock()
+	p.entries[token.GetUID()] = entry
+	p.mu.Unlock()
+
+	return entry.client, nil
+}
+
+// Invalidate drops the client of the token resource with the given uid, it is called when the token is rotated
+func (p *NginxPMClientPool) Invalidate(uid types.UID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.entries, uid)
+}
+
+// InvalidateClient drops the given client from the pool, it is called when Nginx Proxy Manager rejects its token
+func (p *NginxPMClientPool) InvalidateClient(nginxpmClient *nginxpm.Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for uid, entry := range p.entries {
+		if entry.client == nginxpmClient {
+			delete(p.entries, uid)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+func TestNginxPMClientPool(t *testing.T) {
+	accessChecks := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/users/me" {
+			accessChecks++
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	builds := 0
+	build := func(httpClient *http.Client) (*nginxpm.Client, error) {
+		builds++
+		return nginxpm.NewClient(httpClient, server.URL), nil
+	}
+
+	pool := NewNginxPMClientPool(time.Hour)
+	token := &nginxpmoperatoriov1.Token{
+		ObjectMeta: metav1.ObjectMeta{UID: "token-uid", ResourceVersion: "1"},
+	}
+
+	first, err := pool.Get(context.Background(), token, build)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	second, err := pool.Get(context.Background(), token, build)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if first != second || builds != 1 || accessChecks != 1 {
+		t.Errorf("Expected the pooled client to be reused, got %d builds and %d access checks", builds, accessChecks)
+	}
+
+	// A new resourceVersion means the token was rotated
+	token.ResourceVersion = "2"
+	if _, err := pool.Get(context.Background(), token, build); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pool.Invalidate(token.UID)
+	if _, err := pool.Get(context.Background(), token, build); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if builds != 3 || accessChecks != 3 {
+		t.Errorf("Expected 3 builds and 3 access checks, got %d and %d", builds, accessChecks)
+	}
+}
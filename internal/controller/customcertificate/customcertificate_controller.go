@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -111,6 +112,18 @@ func (r *CustomCertificateReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
+	// Leave the certificate in NPM alone while the reconciliation is suspended,
+	// the finalizer is kept so a deletion waits until the resource is resumed
+	if controller.IsSuspended(cc, cc.Spec.Suspend) {
+		log.Info("Reconciliation is suspended, skipping")
+
+		controller.UpdateStatus(ctx, r.Client, cc, req.NamespacedName, func() {
+			controller.SetSuspendedCondition(&cc.Status.Conditions, cc.Generation, true)
+		})
+
+		return ctrl.Result{}, nil
+	}
+
 	// Let's just set the status as Unknown when no status is available
 	if len(cc.Status.Conditions) == 0 {
 		controller.UpdateStatus(ctx, r.Client, cc, req.NamespacedName, func() {
@@ -126,7 +139,7 @@ func (r *CustomCertificateReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	// Create a new Nginx Proxy Manager client
 	// If the client can't be created, we will remove the finalizer
-	nginxpmClient, err := controller.InitNginxPMClient(ctx, r, req, cc.Spec.Token)
+	nginxpmClient, tokenRef, err := controller.InitNginxPMClient(ctx, r, req, cc.Spec.Token)
 	if err != nil {
 		// Stop reconciliation if the resource is marked for deletion and the client can't be created
 		if isMarkedToBeDeleted {
@@ -165,9 +178,10 @@ func (r *CustomCertificateReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			log.Info("Performing Finalizer Operations for CustomCertificate")
 
 			// Delete the CustomCertificate record from remote  Nginx Proxy Manager instance
-			if cc.Status.Id != nil {
+			deletionPolicy := controller.DeletionPolicy(cc.Spec.DeletionPolicy, false, false, controller.DELETION_POLICY_ORPHAN)
+			if cc.Status.Id != nil && deletionPolicy == controller.DELETION_POLICY_DELETE {
 				log.Info("Deleting CustomCertificate record from remote NPM")
-				err := nginxpmClient.DeleteCertificate(int(*cc.Status.Id))
+				err := nginxpmClient.DeleteCertificateContext(ctx, int(*cc.Status.Id))
 
 				if err != nil {
 					log.Error(err, "Failed to delete CustomCertificate record from remote NPM")
@@ -183,6 +197,12 @@ func (r *CustomCertificateReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
+	// Pin the token found by the fallback lookup, the resource keeps using it from now on
+	if err := controller.PinTokenReference(ctx, r.Client, cc, &cc.Spec.Token, tokenRef); err != nil {
+		log.Error(err, "Failed to pin the token reference of CustomCertificate")
+		return ctrl.Result{}, err
+	}
+
 	// Create Certificate or update the existing one
 	result, err := r.createCertificate(ctx, req, cc, nginxpmClient)
 	if err != nil {
@@ -202,6 +222,8 @@ func (r *CustomCertificateReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	// Set the status as True when the client can be created
 	controller.UpdateStatus(ctx, r.Client, cc, req.NamespacedName, func() {
+		cc.Status.TokenRef = tokenRef
+		controller.SetSuspendedCondition(&cc.Status.Conditions, cc.Generation, false)
 		meta.SetStatusCondition(&cc.Status.Conditions, metav1.Condition{
 			Status:             metav1.ConditionTrue,
 			Type:               controller.ConditionTypeReady,
@@ -211,7 +233,7 @@ func (r *CustomCertificateReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		})
 	})
 
-	return ctrl.Result{}, nil
+	return controller.ResyncResult(), nil
 }
 
 func (r *CustomCertificateReconciler) createCertificate(ctx context.Context, req ctrl.Request, cc *nginxpmoperatoriov1.CustomCertificate, nginxpmClient *nginxpm.Client) (ctrl.Result, error) {
@@ -222,7 +244,7 @@ func (r *CustomCertificateReconciler) createCertificate(ctx context.Context, req
 
 	// Let's check if the CustomCertificate is already created
 	if cc.Status.Id != nil {
-		certificate, err = nginxpmClient.FindCustomCertificateByID(*cc.Status.Id)
+		certificate, err = nginxpmClient.FindCustomCertificateByIDContext(ctx, *cc.Status.Id)
 		if err != nil {
 			log.Error(err, "Failed to find CustomCertificate by ID")
 
@@ -231,14 +253,19 @@ func (r *CustomCertificateReconciler) createCertificate(ctx context.Context, req
 				cc.Status.Status = &msg
 			})
 
-			return ctrl.Result{RequeueAfter: time.Minute}, err
+			return controller.ResultForNginxPMError(nginxpmClient, err)
 		}
 
-		return ctrl.Result{}, nil
+		if certificate != nil {
+			return ctrl.Result{}, r.reportDrift(ctx, req, cc, certificate)
+		}
+
+		// The certificate was deleted from Nginx Proxy Manager, let's create it again
+		log.Info("CustomCertificate not found in Nginx Proxy Manager, creating it again", "id", *cc.Status.Id)
 	}
 
 	// Let's create a new CustomCertificate from the CustomCertificate resource
-	if cc.Status.Id == nil {
+	if certificate == nil {
 		log.Info("Creating CustomCertificate")
 
 		// Retrieve the certificate and certificate key from the secret
@@ -262,7 +289,7 @@ func (r *CustomCertificateReconciler) createCertificate(ctx context.Context, req
 			fmt.Sprintf("Creating CustomCertificate, Cert Name: %s, Namespace: %s", niceName, req.Namespace),
 		)
 
-		certificate, err = nginxpmClient.CreateCustomCertificate(
+		certificate, err = nginxpmClient.CreateCustomCertificateContext(ctx,
 			nginxpm.CreateCustomCertificateRequest{
 				NiceName:       niceName,
 				Provider:       nginxpm.CUSTOM_PROVIDER,
@@ -303,6 +330,36 @@ func (r *CustomCertificateReconciler) createCertificate(ctx context.Context, req
 	})
 }
 
+// reportDrift reports in the Drifted condition the changes made to the certificate outside of the operator,
+// a certificate can't be updated through the API so they are not corrected
+func (r *CustomCertificateReconciler) reportDrift(ctx context.Context, req ctrl.Request, cc *nginxpmoperatoriov1.CustomCertificate, certificate *nginxpm.CustomCertificate) error {
+	niceName := req.Name
+	if cc.Spec.NiceName != nil && len(*cc.Spec.NiceName) > 0 {
+		niceName = *cc.Spec.NiceName
+	}
+
+	var drift []string
+	if certificate.NiceName != niceName {
+		drift = append(drift, fmt.Sprintf("nice_name: expected %s, found %s", niceName, certificate.NiceName))
+	}
+
+	if cc.Status.ExpiresOn != nil && *cc.Status.ExpiresOn != certificate.ExpiresOn {
+		drift = append(drift, fmt.Sprintf("expires_on: expected %s, found %s", *cc.Status.ExpiresOn, certificate.ExpiresOn))
+	}
+
+	if len(drift) > 0 && controller.DriftChanged(cc.Status.Conditions, controller.DRIFT_POLICY_REPORT, drift) {
+		r.Recorder.Event(
+			cc, "Warning", "DriftDetected",
+			fmt.Sprintf("Certificate changed outside of the operator, ResourceName: %s, Namespace: %s, drift: %s",
+				req.Name, req.Namespace, strings.Join(drift, "; ")),
+		)
+	}
+
+	return controller.UpdateStatus(ctx, r.Client, cc, req.NamespacedName, func() {
+		controller.SetDriftedCondition(&cc.Status.Conditions, cc.Generation, controller.DRIFT_POLICY_REPORT, drift)
+	})
+}
+
 func (r *CustomCertificateReconciler) getCertificateKeys(ctx context.Context, req ctrl.Request, cc *nginxpmoperatoriov1.CustomCertificate) (*CustomCertificateKeys, error) {
 	log := log.FromContext(ctx)
 
@@ -321,8 +378,12 @@ func (r *CustomCertificateReconciler) getCertificateKeys(ctx context.Context, re
 		return nil, err
 	}
 
-	// Get the certificate from the secret data
+	// Get the certificate from the secret data,
+	// kubernetes.io/tls secrets (e.g. from Ingress tls) use the tls.crt and tls.key fields instead
 	certificate, ok := secret.Data["certificate"]
+	if !ok {
+		certificate, ok = secret.Data[corev1.TLSCertKey]
+	}
 	if !ok {
 		err := errors.New("failed to get [certificate] field from secret")
 		log.Error(err, "failed to get [certificate] field from secret")
@@ -331,6 +392,9 @@ func (r *CustomCertificateReconciler) getCertificateKeys(ctx context.Context, re
 
 	// Get the certificate key from the secret data
 	certificateKey, ok := secret.Data["certificate_key"]
+	if !ok {
+		certificateKey, ok = secret.Data[corev1.TLSPrivateKeyKey]
+	}
 	if !ok {
 		err := errors.New("failed to get [certificate_key] field from secret")
 		log.Error(err, "failed to get [certificate_key] field from secret")
@@ -400,6 +464,11 @@ func (r *CustomCertificateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(CC_TOKEN_FIELD)),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&nginxpmoperatoriov1.ClusterToken{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(CC_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Named("customcertificate").
 		Complete(r)
 }
@@ -0,0 +1,589 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadhost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+const (
+	deadHostFinalizer = "deadhost.nginxpm-operator.io/finalizers"
+
+	DH_TOKEN_FIELD = ".spec.token.name"
+
+	DH_CUSTOM_CERTIFICATE_FIELD = ".spec.ssl.customCertificate.name"
+
+	DH_LETSENCRYPT_CERTIFICATE_FIELD = ".spec.ssl.letsEncryptCertificate.name"
+)
+
+// DeadHostReconciler reconciles a DeadHost object
+type DeadHostReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=deadhosts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=deadhosts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=deadhosts/finalizers,verbs=update
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens/status,verbs=get
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=customcertificates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=customcertificates/status,verbs=get
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=letsencryptcertificates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=letsencryptcertificates/status,verbs=get
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// the DeadHost object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *DeadHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	dh := &nginxpmoperatoriov1.DeadHost{}
+
+	// Fetch the DeadHost instance
+	err := r.Get(ctx, req.NamespacedName, dh)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// If the custom resource is not found then it usually means that it was deleted or not created
+			// In this way, we will stop the reconciliation
+			log.Info("deadHost resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		log.Error(err, "Failed to get deadHost")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	isMarkedToBeDeleted := !dh.ObjectMeta.DeletionTimestamp.IsZero()
+
+	// Let's add a finalizer. Then, we can define some operations which should
+	// occur before the custom resource to be deleted.
+	if !isMarkedToBeDeleted {
+		if err := controller.AddFinalizer(r, ctx, deadHostFinalizer, dh); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+	}
+
+	// Leave the 404 host in NPM alone while the reconciliation is suspended,
+	// the finalizer is kept so a deletion waits until the resource is resumed
+	if controller.IsSuspended(dh, dh.Spec.Suspend) {
+		log.Info("Reconciliation is suspended, skipping")
+
+		controller.UpdateStatus(ctx, r.Client, dh, req.NamespacedName, func() {
+			controller.SetSuspendedCondition(&dh.Status.Conditions, dh.Generation, true)
+		})
+
+		return ctrl.Result{}, nil
+	}
+
+	// Let's just set the status as Unknown when no status is available
+	if len(dh.Status.Conditions) == 0 {
+		controller.UpdateStatus(ctx, r.Client, dh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&dh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionUnknown,
+				Type:               controller.ConditionTypeReconciling,
+				Reason:             "Reconciling",
+				Message:            "Starting reconciliation",
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+	}
+
+	// Create a new Nginx Proxy Manager client
+	nginxpmClient, tokenRef, err := controller.InitNginxPMClient(ctx, r, req, dh.Spec.Token)
+	if err != nil {
+		// Stop reconciliation if the resource is marked for deletion and the client can't be created
+		if isMarkedToBeDeleted {
+			// Re-fetch the resource to get the latest version before removing finalizer
+			latestDh := &nginxpmoperatoriov1.DeadHost{}
+			if err := r.Get(ctx, req.NamespacedName, latestDh); err != nil {
+				if apierrors.IsNotFound(err) {
+					return ctrl.Result{}, nil
+				}
+				log.Error(err, "Failed to re-fetch DeadHost before removing finalizer")
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, err
+			}
+
+			if err := controller.RemoveFinalizer(r, ctx, deadHostFinalizer, latestDh); err != nil {
+				if apierrors.IsConflict(err) {
+					log.Info("Conflict error when removing finalizer, will retry")
+					return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+				}
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+
+			return ctrl.Result{}, nil
+		}
+
+		r.Recorder.Event(
+			dh, "Warning", "InitNginxPMClient",
+			fmt.Sprintf("Failed to init nginxpm client: ResourceName: %s, Namespace: %s, err: %s",
+				req.Name, req.Namespace, err.Error()),
+		)
+
+		// Set the status as False when the client can't be created
+		controller.UpdateStatus(ctx, r.Client, dh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&dh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "InitNginxPMClient",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Delete the DeadHost record from remote Nginx Proxy Manager instance before deleting the resource
+	if isMarkedToBeDeleted {
+		if controllerutil.ContainsFinalizer(dh, deadHostFinalizer) {
+			log.Info("Performing Finalizer Operations for DeadHost")
+
+			if dh.Status.Id != nil {
+				// No initial configuration is captured for dead hosts, so there is nothing to restore
+				deletionPolicy := controller.DeletionPolicy(dh.Spec.DeletionPolicy, dh.Status.Bound, false, controller.DELETION_POLICY_DISABLE)
+
+				switch deletionPolicy {
+				case controller.DELETION_POLICY_DISABLE:
+					log.Info("Disabling DeadHost record from remote NPM")
+
+					err := nginxpmClient.DisableDeadHostContext(ctx, *dh.Status.Id)
+					if err != nil {
+						log.Error(err, "Failed to disable DeadHost record from remote NPM")
+					}
+				case controller.DELETION_POLICY_DELETE:
+					log.Info("Deleting DeadHost record from remote NPM")
+
+					err := nginxpmClient.DeleteDeadHostContext(ctx, *dh.Status.Id)
+					if err != nil {
+						log.Error(err, "Failed to delete DeadHost record from remote NPM")
+					}
+				default:
+					log.Info("Leaving DeadHost record in remote NPM", "deletionPolicy", deletionPolicy)
+				}
+			}
+
+			latestDh := &nginxpmoperatoriov1.DeadHost{}
+			if err := r.Get(ctx, req.NamespacedName, latestDh); err != nil {
+				if apierrors.IsNotFound(err) {
+					return ctrl.Result{}, nil
+				}
+
+				log.Error(err, "Failed to re-fetch DeadHost before removing finalizer")
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, err
+			}
+
+			if err := controller.RemoveFinalizer(r, ctx, deadHostFinalizer, latestDh); err != nil {
+				if apierrors.IsConflict(err) {
+					log.Info("Conflict error when removing finalizer, will retry")
+					return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+				}
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Pin the token found by the fallback lookup, the resource keeps using it from now on
+	if err := controller.PinTokenReference(ctx, r.Client, dh, &dh.Spec.Token, tokenRef); err != nil {
+		log.Error(err, "Failed to pin the token reference of DeadHost")
+		return ctrl.Result{}, err
+	}
+
+	// Domains should be unique
+	if err := r.domainsShouldBeUnique(ctx, dh); err != nil {
+		controller.UpdateStatus(ctx, r.Client, dh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&dh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "DomainsShouldBeUnique",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Create or update dead host
+	err = r.createOrUpdateDeadHost(ctx, req, dh, nginxpmClient)
+	if err != nil {
+		controller.UpdateStatus(ctx, r.Client, dh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&dh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "CreateOrUpdateDeadHost",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return controller.ResultForNginxPMError(nginxpmClient, err)
+	}
+
+	controller.UpdateStatus(ctx, r.Client, dh, req.NamespacedName, func() {
+		dh.Status.TokenRef = tokenRef
+		controller.SetSuspendedCondition(&dh.Status.Conditions, dh.Generation, false)
+		meta.SetStatusCondition(&dh.Status.Conditions, metav1.Condition{
+			Status:             metav1.ConditionTrue,
+			Type:               controller.ConditionTypeReady,
+			Reason:             "CreateOrUpdateDeadHost",
+			Message:            fmt.Sprintf("Dead host created or updated, ResourceName: %s", req.Name),
+			LastTransitionTime: metav1.Now(),
+		})
+	})
+
+	return ctrl.Result{}, nil
+}
+
+func (r *DeadHostReconciler) domainsShouldBeUnique(ctx context.Context, dh *nginxpmoperatoriov1.DeadHost) error {
+	log := log.FromContext(ctx)
+
+	deadHosts := &nginxpmoperatoriov1.DeadHostList{}
+	if err := r.List(ctx, deadHosts); err != nil {
+		log.Error(err, "Failed to list dead hosts")
+		return err
+	}
+
+	domains := extractDomains(dh)
+
+	for _, deadHost := range deadHosts.Items {
+		if deadHost.GetName() == dh.GetName() && deadHost.GetNamespace() == dh.GetNamespace() {
+			continue
+		}
+
+		deadHostDomains := extractDomains(&deadHost)
+		for _, domain := range domains {
+			if slices.Contains(deadHostDomains, domain) {
+				msg := fmt.Sprintf("Domain %s is already used by another dead host: (name: %s, namespace: %s)",
+					domain, deadHost.GetName(), deadHost.GetNamespace())
+
+				err := errors.New(msg)
+				log.Error(err, msg)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *DeadHostReconciler) createOrUpdateDeadHost(ctx context.Context, req ctrl.Request, dh *nginxpmoperatoriov1.DeadHost, nginxpmClient *nginxpm.Client) error {
+	log := log.FromContext(ctx)
+
+	var deadHost *nginxpm.DeadHost
+	var err error
+
+	bound := dh.Status.Bound
+	domains := extractDomains(dh)
+
+	// Let's check if the dead host is already created
+	if dh.Status.Id != nil {
+		deadHost, err = nginxpmClient.FindDeadHostByIDContext(ctx, *dh.Status.Id)
+		if err != nil {
+			r.Recorder.Event(
+				dh, "Warning", "FindDeadHostByID",
+				fmt.Sprintf("Failed to find dead host by ID, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to find dead host by ID")
+			return err
+		}
+	} else if dh.Spec.BindExisting {
+		// If finding by ID doesn't match a record, we search for the dead host by domain.
+		deadHost, _ = nginxpmClient.FindDeadHostByDomainContext(ctx, domains)
+
+		if deadHost != nil {
+			bound = true
+		}
+	}
+
+	// Enable DeadHost if disabled
+	if deadHost != nil && !deadHost.Enabled {
+		log.Info("Enabling DeadHost")
+		nginxpmClient.EnableDeadHostContext(ctx, deadHost.ID)
+	}
+
+	// Certificate operation
+	var certificateID *int
+	if dh.Spec.Ssl != nil {
+		certificate, err := r.makeCertificate(ctx, req, dh, nginxpmClient)
+		if err != nil {
+			r.Recorder.Event(
+				dh, "Warning", "MakeCertificate",
+				fmt.Sprintf("Failed to make certificate, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+			return err
+		}
+
+		if certificate != nil {
+			certificateID = &certificate.ID
+		}
+	}
+
+	input := nginxpm.DeadHostRequestInput{
+		DomainNames:    domains,
+		AdvancedConfig: dh.Spec.AdvancedConfig,
+		CertificateID:  certificateID,
+	}
+
+	// Handle SSL fields
+	if dh.Spec.Ssl != nil {
+		input.SSLForced = dh.Spec.Ssl.SslForced
+		input.HTTP2Support = dh.Spec.Ssl.Http2Support
+		input.HSTSEnabled = dh.Spec.Ssl.HstsEnabled
+		input.HSTSSubdomains = dh.Spec.Ssl.HstsSubdomains
+	}
+
+	if deadHost != nil {
+		deadHost, err = nginxpmClient.UpdateDeadHostContext(ctx, deadHost.ID, input)
+		if err != nil {
+			r.Recorder.Event(
+				dh, "Warning", "UpdateDeadHost",
+				fmt.Sprintf("Failed to update dead host, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to update dead host")
+			return err
+		}
+
+		log.Info("DeadHost updated successfully")
+	} else {
+		deadHost, err = nginxpmClient.CreateDeadHostContext(ctx, input)
+		if err != nil {
+			r.Recorder.Event(
+				dh, "Warning", "CreateDeadHost",
+				fmt.Sprintf("Failed to create dead host, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to create dead host")
+			return err
+		}
+
+		log.Info("DeadHost created successfully")
+	}
+
+	return controller.UpdateStatus(ctx, r.Client, dh, req.NamespacedName, func() {
+		dh.Status.Id = &deadHost.ID
+		dh.Status.Online = deadHost.Meta.NginxOnline
+		dh.Status.CertificateId = certificateID
+		dh.Status.Bound = bound
+	})
+}
+
+// ############################################# CERTIFICATE OPERATION ##############################################
+
+func (r *DeadHostReconciler) makeCertificate(ctx context.Context, req ctrl.Request, dh *nginxpmoperatoriov1.DeadHost, nginxpmClient *nginxpm.Client) (*nginxpm.Certificate, error) {
+	log := log.FromContext(ctx)
+
+	if dh.Spec.Ssl == nil {
+		log.Info("SSL is not enabled, skipping certificate operation")
+		return nil, nil
+	}
+
+	certificate, err := controller.RetrieveCertificate(controller.RetrieveCertificateOption{
+		Cxt:                    ctx,
+		Req:                    req,
+		Reader:                 r,
+		NginxpmClient:          nginxpmClient,
+		LetsEncryptCertificate: dh.Spec.Ssl.LetsEncryptCertificate,
+		CustomCertificate:      dh.Spec.Ssl.CustomCertificate,
+		CertificateId:          dh.Spec.Ssl.CertificateId,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if dh.Spec.Ssl.AutoCertificateRequest && certificate == nil {
+		log.Info("Since no LetsEncryptCertificate, CustomCertificate, or CertificateId is provided, AutoCertificateRequest is enabled, finding or creating certificate")
+		certificate, err = controller.FindOrCreateCertificate(ctx, nginxpmClient, extractDomains(dh), dh.Spec.Ssl.LetsEncryptEmail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return certificate, nil
+}
+
+// ############################################# UTILS ##############################################
+
+func extractDomains(dh *nginxpmoperatoriov1.DeadHost) []string {
+	domains := make([]string, len(dh.Spec.DomainNames))
+	for i, domain := range dh.Spec.DomainNames {
+		domains[i] = string(domain)
+	}
+
+	return domains
+}
+
+// ############################################# CONTROLLER ##############################################
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DeadHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Add the Token to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+
+		&nginxpmoperatoriov1.DeadHost{},
+
+		DH_TOKEN_FIELD,
+
+		func(rawObj client.Object) []string {
+			dh := rawObj.(*nginxpmoperatoriov1.DeadHost)
+
+			if dh.Spec.Token == nil {
+				// If token is not provided, use the default token name
+				return []string{controller.TOKEN_DEFAULT_NAME}
+			}
+
+			if dh.Spec.Token.Name == "" {
+				return nil
+			}
+
+			return []string{dh.Spec.Token.Name}
+		}); err != nil {
+		return err
+	}
+
+	// Add the CustomCertificate to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+
+		&nginxpmoperatoriov1.DeadHost{},
+
+		DH_CUSTOM_CERTIFICATE_FIELD,
+
+		func(rawObj client.Object) []string {
+			dh := rawObj.(*nginxpmoperatoriov1.DeadHost)
+			if dh.Spec.Ssl == nil || dh.Spec.Ssl.CustomCertificate == nil || dh.Spec.Ssl.CustomCertificate.Name == "" {
+				return nil
+			}
+			return []string{dh.Spec.Ssl.CustomCertificate.Name}
+		}); err != nil {
+		return err
+	}
+
+	// Add the LetsEncryptCertificate to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+
+		&nginxpmoperatoriov1.DeadHost{},
+
+		DH_LETSENCRYPT_CERTIFICATE_FIELD,
+
+		func(rawObj client.Object) []string {
+			dh := rawObj.(*nginxpmoperatoriov1.DeadHost)
+			if dh.Spec.Ssl == nil || dh.Spec.Ssl.LetsEncryptCertificate == nil || dh.Spec.Ssl.LetsEncryptCertificate.Name == "" {
+				return nil
+			}
+			return []string{dh.Spec.Ssl.LetsEncryptCertificate.Name}
+		}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&nginxpmoperatoriov1.DeadHost{}).
+		Owns(&nginxpmoperatoriov1.Token{}).
+		Owns(&nginxpmoperatoriov1.CustomCertificate{}).
+		Owns(&nginxpmoperatoriov1.LetsEncryptCertificate{}).
+		Watches(
+			&nginxpmoperatoriov1.Token{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(DH_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&nginxpmoperatoriov1.ClusterToken{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(DH_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&nginxpmoperatoriov1.CustomCertificate{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(DH_CUSTOM_CERTIFICATE_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&nginxpmoperatoriov1.LetsEncryptCertificate{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(DH_LETSENCRYPT_CERTIFICATE_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Named("deadhost").
+		Complete(r)
+}
+
+func (r *DeadHostReconciler) findObjectsForMap(field string) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, object client.Object) []reconcile.Request {
+		attachedObjects := &nginxpmoperatoriov1.DeadHostList{}
+
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(field, object.GetName()),
+		}
+
+		err := r.List(ctx, attachedObjects, listOps)
+		if err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, len(attachedObjects.Items))
+		for i, item := range attachedObjects.Items {
+			requests[i] = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			}
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadhost
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/test/fakenpm"
+)
+
+const collection = "dead-hosts"
+
+func newReconciler(server *fakenpm.Server, objects ...client.Object) *DeadHostReconciler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = nginxpmoperatoriov1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(server.Token(controller.TOKEN_DEFAULT_NAME, "default"), objects...)...).
+		WithStatusSubresource(&nginxpmoperatoriov1.DeadHost{}, &nginxpmoperatoriov1.LetsEncryptCertificate{}).
+		Build()
+
+	return &DeadHostReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+}
+
+func reconcileDeadHost(t *testing.T, r *DeadHostReconciler, dh *nginxpmoperatoriov1.DeadHost) error {
+	t.Helper()
+
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dh)}
+	_, reconcileErr := r.Reconcile(context.Background(), req)
+
+	if err := r.Get(context.Background(), req.NamespacedName, dh); err != nil && !apierrors.IsNotFound(err) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return reconcileErr
+}
+
+func TestReconcileCertificate(t *testing.T) {
+	server := fakenpm.NewServer()
+	defer server.Close()
+
+	certificateID := server.Add("certificates", map[string]interface{}{
+		"provider":     "letsencrypt",
+		"domain_names": []string{"*.example.com"},
+	})
+
+	lec := &nginxpmoperatoriov1.LetsEncryptCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "wildcard", Namespace: "default"},
+		Spec:       nginxpmoperatoriov1.LetsEncryptCertificateSpec{DomainNames: []nginxpmoperatoriov1.DomainName{"*.example.com"}},
+	}
+
+	dh := &nginxpmoperatoriov1.DeadHost{
+		ObjectMeta: metav1.ObjectMeta{Name: "parked", Namespace: "default"},
+		Spec: nginxpmoperatoriov1.DeadHostSpec{
+			DomainNames: []nginxpmoperatoriov1.DomainName{"parked.example.com"},
+			Ssl: &nginxpmoperatoriov1.ProxyHostSsl{
+				LetsEncryptCertificate: &nginxpmoperatoriov1.SslLetsEncryptCertificate{Name: lec.Name},
+				SslForced:              true,
+			},
+		},
+	}
+
+	r := newReconciler(server, lec, dh)
+
+	// The certificate has not been issued yet, the dead host waits for it
+	if err := reconcileDeadHost(t, r, dh); err == nil {
+		t.Fatal("Expected an error while the certificate is not issued")
+	}
+	if dh.Status.Id != nil || !meta.IsStatusConditionFalse(dh.Status.Conditions, controller.ConditionTypeError) {
+		t.Fatalf("Expected an Error condition and no dead host, got %+v", dh.Status)
+	}
+
+	if err := r.Get(context.Background(), client.ObjectKeyFromObject(lec), lec); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lec.Status.Id = &certificateID
+	if err := r.Status().Update(context.Background(), lec); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := reconcileDeadHost(t, r, dh); err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+
+	if dh.Status.Id == nil || dh.Status.CertificateId == nil || *dh.Status.CertificateId != certificateID {
+		t.Fatalf("Expected a dead host using certificate %d, got %+v", certificateID, dh.Status)
+	}
+
+	deadHost := server.Get(collection, *dh.Status.Id)
+	if deadHost["certificate_id"] != float64(certificateID) || deadHost["ssl_forced"] != true {
+		t.Errorf("Expected the dead host to be created with the certificate, got %v", deadHost)
+	}
+}
+
+func TestReconcileDeletion(t *testing.T) {
+	server := fakenpm.NewServer()
+	defer server.Close()
+
+	boundID := server.Add(collection, map[string]interface{}{"domain_names": []string{"old.example.com"}, "enabled": false})
+
+	bound := &nginxpmoperatoriov1.DeadHost{
+		ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default"},
+		Spec: nginxpmoperatoriov1.DeadHostSpec{
+			DomainNames:  []nginxpmoperatoriov1.DomainName{"old.example.com"},
+			BindExisting: true,
+		},
+	}
+	created := &nginxpmoperatoriov1.DeadHost{
+		ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default"},
+		Spec:       nginxpmoperatoriov1.DeadHostSpec{DomainNames: []nginxpmoperatoriov1.DomainName{"new.example.com"}},
+	}
+
+	r := newReconciler(server, bound, created)
+
+	for _, dh := range []*nginxpmoperatoriov1.DeadHost{bound, created} {
+		if err := reconcileDeadHost(t, r, dh); err != nil {
+			t.Fatalf("Unexpected reconcile error: %v", err)
+		}
+	}
+
+	if bound.Status.Id == nil || *bound.Status.Id != boundID || !bound.Status.Bound {
+		t.Fatalf("Expected the existing dead host %d to be bound, got %+v", boundID, bound.Status)
+	}
+	if server.Get(collection, boundID)["enabled"] != true {
+		t.Error("Expected the bound dead host to be enabled")
+	}
+	if created.Status.Id == nil || created.Status.Bound {
+		t.Fatalf("Expected a dead host to be created, got %+v", created.Status)
+	}
+	createdID := *created.Status.Id
+
+	for _, dh := range []*nginxpmoperatoriov1.DeadHost{bound, created} {
+		if err := r.Delete(context.Background(), dh); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := reconcileDeadHost(t, r, dh); err != nil {
+			t.Fatalf("Unexpected reconcile error: %v", err)
+		}
+	}
+
+	// Nothing is captured to restore a dead host, the bound one is disabled and the created one deleted
+	if deadHost := server.Get(collection, boundID); deadHost == nil || deadHost["enabled"] != false {
+		t.Errorf("Expected the bound dead host to be disabled, got %v", deadHost)
+	}
+	if deadHost := server.Get(collection, createdID); deadHost != nil {
+		t.Errorf("Expected the created dead host to be deleted, got %v", deadHost)
+	}
+}
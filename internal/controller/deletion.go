@@ -0,0 +1,55 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+const (
+	// Values of the deletionPolicy field of the resources
+	DELETION_POLICY_DELETE  = "Delete"
+	DELETION_POLICY_ORPHAN  = "Orphan"
+	DELETION_POLICY_DISABLE = "Disable"
+	DELETION_POLICY_RESTORE = "Restore"
+)
+
+// DeletionPolicy resolves the deletion policy of a resource to what its finalizer does with the object.
+// restorable tells whether the initial configuration of the bound object was captured, fallback is
+// what is done with the bound objects which can't be restored.
+//
+// Without policy, the objects created by the operator are deleted and the bound objects are restored
+// when they can be. An explicit Restore without captured initial configuration resolves to fallback,
+// the object is never deleted when the user asked for it to be restored.
+func DeletionPolicy(policy string, bound, restorable bool, fallback string) string {
+	switch policy {
+	case DELETION_POLICY_DELETE, DELETION_POLICY_ORPHAN, DELETION_POLICY_DISABLE:
+		return policy
+	case DELETION_POLICY_RESTORE:
+		if bound && restorable {
+			return DELETION_POLICY_RESTORE
+		}
+
+		return fallback
+	}
+
+	if !bound {
+		return DELETION_POLICY_DELETE
+	}
+
+	if restorable {
+		return DELETION_POLICY_RESTORE
+	}
+
+	return fallback
+}
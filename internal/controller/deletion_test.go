@@ -0,0 +1,42 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import "testing"
+
+func TestDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		policy     string
+		bound      bool
+		restorable bool
+		want       string
+	}{
+		{"", false, false, DELETION_POLICY_DELETE},
+		{"", true, true, DELETION_POLICY_RESTORE},
+		{"", true, false, DELETION_POLICY_DISABLE},
+		{DELETION_POLICY_ORPHAN, false, false, DELETION_POLICY_ORPHAN},
+		{DELETION_POLICY_DELETE, true, true, DELETION_POLICY_DELETE},
+		{DELETION_POLICY_RESTORE, true, false, DELETION_POLICY_DISABLE},
+		{DELETION_POLICY_RESTORE, false, false, DELETION_POLICY_DISABLE},
+	}
+
+	for _, tt := range tests {
+		if got := DeletionPolicy(tt.policy, tt.bound, tt.restorable, DELETION_POLICY_DISABLE); got != tt.want {
+			t.Errorf("DeletionPolicy(%q, %v, %v) = %q, want %q", tt.policy, tt.bound, tt.restorable, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// Values of the driftPolicy field of the resources
+	DRIFT_POLICY_IGNORE  = "Ignore"
+	DRIFT_POLICY_REPORT  = "Report"
+	DRIFT_POLICY_CORRECT = "Correct"
+
+	// DEFAULT_RESYNC_PERIOD is how often the resources are compared with Nginx Proxy Manager by default
+	DEFAULT_RESYNC_PERIOD = 5 * time.Minute
+)
+
+// ResyncPeriod is how often the reconcilers compare their resources with Nginx Proxy Manager,
+// to recreate the objects deleted there and to report or revert the changes made outside of the operator.
+// It is set by the --resync-period flag, periodic resyncs are disabled when it is not positive.
+var ResyncPeriod = DEFAULT_RESYNC_PERIOD
+
+// ResyncResult is the result of a successful reconcile, it requeues the resource for its next resync
+func ResyncResult() reconcile.Result {
+	if ResyncPeriod <= 0 {
+		return reconcile.Result{}
+	}
+
+	return reconcile.Result{RequeueAfter: ResyncPeriod}
+}
+
+// DriftPolicy returns the given drift policy, Correct when none is set so the objects
+// changed outside of the operator are put back to their spec
+func DriftPolicy(policy string) string {
+	if policy == "" {
+		return DRIFT_POLICY_CORRECT
+	}
+
+	return policy
+}
+
+// HashConfig returns the hash of the configuration sent to Nginx Proxy Manager for a resource,
+// stored in its status.appliedConfigHash
+func HashConfig(config interface{}) string {
+	data, _ := json.Marshal(config)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckDrift compares a remote object with the configuration built from the spec of its resource.
+// Drift is only looked for when the object exists and the configuration is the one applied last,
+// the configuration must be written otherwise. diff lists the fields of the object which differ
+// from the configuration. It returns that list and whether the object can be left as is.
+func CheckDrift(policy string, exists bool, appliedHash, configHash string, diff func() []string) ([]string, bool) {
+	if !exists || appliedHash != configHash {
+		return nil, false
+	}
+
+	if policy == DRIFT_POLICY_IGNORE {
+		return nil, true
+	}
+
+	drift := diff()
+
+	return drift, len(drift) == 0 || policy != DRIFT_POLICY_CORRECT
+}
+
+// SetDriftedCondition reports the outcome of CheckDrift in the Drifted condition
+func SetDriftedCondition(conditions *[]metav1.Condition, generation int64, policy string, drift []string) {
+	if policy == DRIFT_POLICY_IGNORE {
+		meta.RemoveStatusCondition(conditions, ConditionTypeDrifted)
+		return
+	}
+
+	meta.SetStatusCondition(conditions, driftedCondition(generation, policy, drift))
+}
+
+// DriftChanged reports whether SetDriftedCondition would change the Drifted condition, the drift
+// events are only recorded when it does so the same drift is not reported again on every resync
+func DriftChanged(conditions []metav1.Condition, policy string, drift []string) bool {
+	current := meta.FindStatusCondition(conditions, ConditionTypeDrifted)
+	if current == nil {
+		return true
+	}
+
+	condition := driftedCondition(current.ObservedGeneration, policy, drift)
+
+	return current.Status != condition.Status || current.Reason != condition.Reason || current.Message != condition.Message
+}
+
+func driftedCondition(generation int64, policy string, drift []string) metav1.Condition {
+	condition := metav1.Condition{
+		Status:             metav1.ConditionFalse,
+		Type:               ConditionTypeDrifted,
+		Reason:             "InSync",
+		Message:            "Nginx Proxy Manager matches the spec",
+		ObservedGeneration: generation,
+	}
+
+	if len(drift) > 0 {
+		if policy == DRIFT_POLICY_CORRECT {
+			condition.Reason = "DriftCorrected"
+			condition.Message = "Reverted changes made outside of the operator: " + strings.Join(drift, "; ")
+		} else {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "DriftDetected"
+			condition.Message = strings.Join(drift, "; ")
+		}
+	}
+
+	return condition
+}
+
+// SameDomains compares domains regardless of their order and case, as NPM stores them lowercased
+func SameDomains(found, expected []string) bool {
+	if len(found) != len(expected) {
+		return false
+	}
+
+	normalize := func(domains []string) []string {
+		normalized := make([]string, len(domains))
+		for i, domain := range domains {
+			normalized[i] = strings.ToLower(domain)
+		}
+		slices.Sort(normalized)
+		return normalized
+	}
+
+	return slices.Equal(normalize(found), normalize(expected))
+}
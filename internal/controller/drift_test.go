@@ -0,0 +1,69 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckDrift(t *testing.T) {
+	drifted := func() []string { return []string{"enabled: expected true, found false"} }
+
+	if _, upToDate := CheckDrift(DRIFT_POLICY_REPORT, false, "hash", "hash", drifted); upToDate {
+		t.Error("Expected a missing object to be written")
+	}
+
+	if _, upToDate := CheckDrift(DRIFT_POLICY_REPORT, true, "old", "hash", drifted); upToDate {
+		t.Error("Expected a changed configuration to be written")
+	}
+
+	if drift, upToDate := CheckDrift(DRIFT_POLICY_IGNORE, true, "hash", "hash", drifted); !upToDate || drift != nil {
+		t.Errorf("Expected the drift to be ignored, got %v", drift)
+	}
+
+	if drift, upToDate := CheckDrift(DRIFT_POLICY_REPORT, true, "hash", "hash", drifted); !upToDate || len(drift) != 1 {
+		t.Errorf("Expected the drift to be reported only, got %v", drift)
+	}
+
+	if drift, upToDate := CheckDrift(DRIFT_POLICY_CORRECT, true, "hash", "hash", drifted); upToDate || len(drift) != 1 {
+		t.Errorf("Expected the drift to be corrected, got %v", drift)
+	}
+}
+
+func TestDriftChanged(t *testing.T) {
+	drift := []string{"enabled: expected true, found false"}
+
+	if DriftPolicy("") != DRIFT_POLICY_CORRECT {
+		t.Errorf("Expected the drift to be corrected by default, got %s", DriftPolicy(""))
+	}
+
+	var conditions []metav1.Condition
+	if !DriftChanged(conditions, DRIFT_POLICY_REPORT, drift) {
+		t.Error("Expected a first drift to change the condition")
+	}
+
+	SetDriftedCondition(&conditions, 1, DRIFT_POLICY_REPORT, drift)
+	if DriftChanged(conditions, DRIFT_POLICY_REPORT, drift) {
+		t.Error("Expected the same drift to leave the condition unchanged")
+	}
+
+	if !DriftChanged(conditions, DRIFT_POLICY_REPORT, []string{"forward_port: expected 80, found 81"}) {
+		t.Error("Expected another drift to change the condition")
+	}
+}
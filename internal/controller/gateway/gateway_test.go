@@ -0,0 +1,289 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+func newClient(objects ...client.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = nginxpmoperatoriov1.AddToScheme(scheme)
+	_ = gatewayv1.Install(scheme)
+	_ = gatewayv1alpha2.Install(scheme)
+	_ = gatewayv1beta1.Install(scheme)
+
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginxpm"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: CONTROLLER_NAME},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "npm", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "nginxpm",
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80, Hostname: ptr.To(gatewayv1.Hostname("*.example.com"))},
+				{Name: "postgres", Protocol: gatewayv1.TCPProtocolType, Port: 5432},
+				{Name: "dns", Protocol: gatewayv1.UDPProtocolType, Port: 53},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append([]client.Object{gatewayClass, gateway}, objects...)...).
+		WithStatusSubresource(&gatewayv1.HTTPRoute{}, &gatewayv1alpha2.TCPRoute{}, &gatewayv1alpha2.UDPRoute{}).
+		Build()
+
+	return c, scheme
+}
+
+func newService(name string, port int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: "10.96.0.10",
+			Ports:     []corev1.ServicePort{{Name: "main", Port: port}},
+		},
+	}
+}
+
+func backendRef(name string, port int32) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name), Port: ptr.To(gatewayv1.PortNumber(port))},
+	}
+}
+
+func parentConditions(t *testing.T, status gatewayv1.RouteStatus) []metav1.Condition {
+	t.Helper()
+
+	if len(status.Parents) != 1 || status.Parents[0].ControllerName != CONTROLLER_NAME {
+		t.Fatalf("Expected a single parent status written by the operator, got %+v", status.Parents)
+	}
+
+	return status.Parents[0].Conditions
+}
+
+func TestHTTPRouteTranslation(t *testing.T) {
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "npm"}}},
+			Hostnames:       []gatewayv1.Hostname{"app.example.com", "app.other.com"},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{
+						Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+						Value: ptr.To("/api"),
+					}}},
+					BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: backendRef("api", 8080)}},
+				},
+				{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: backendRef("web", 80)}}},
+			},
+		},
+	}
+
+	c, scheme := newClient(route, newService("web", 80))
+	r := &HTTPRouteReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(route)}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+
+	ph := &nginxpmoperatoriov1.ProxyHost{}
+	if err := c.Get(context.Background(), req.NamespacedName, ph); err != nil {
+		t.Fatalf("Expected a ProxyHost to be generated: %v", err)
+	}
+
+	// Only the hostnames accepted by the listener are kept
+	if len(ph.Spec.DomainNames) != 1 || ph.Spec.DomainNames[0] != "app.example.com" {
+		t.Errorf("Expected the app.example.com domain, got %v", ph.Spec.DomainNames)
+	}
+	if ph.Spec.Forward.Service == nil || ph.Spec.Forward.Service.Name != "web" {
+		t.Errorf("Expected the root rule to be the main forward, got %+v", ph.Spec.Forward)
+	}
+	if len(ph.Spec.CustomLocations) != 1 || ph.Spec.CustomLocations[0].LocationPath != "/api" ||
+		ph.Spec.CustomLocations[0].Forward.Service.Name != "api" || *ph.Spec.CustomLocations[0].Forward.Service.Port != 8080 {
+		t.Errorf("Expected a /api custom location forwarding to api:8080, got %+v", ph.Spec.CustomLocations)
+	}
+	if !metav1.IsControlledBy(ph, route) {
+		t.Error("Expected the ProxyHost to be controlled by the route")
+	}
+
+	// The api service does not exist yet
+	if err := c.Get(context.Background(), req.NamespacedName, route); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	conditions := parentConditions(t, route.Status.RouteStatus)
+	if !meta.IsStatusConditionTrue(conditions, string(gatewayv1.RouteConditionAccepted)) {
+		t.Errorf("Expected the route to be accepted, got %+v", conditions)
+	}
+	resolvedRefs := meta.FindStatusCondition(conditions, string(gatewayv1.RouteConditionResolvedRefs))
+	if resolvedRefs == nil || resolvedRefs.Status != metav1.ConditionFalse || resolvedRefs.Reason != string(gatewayv1.RouteReasonBackendNotFound) {
+		t.Errorf("Expected ResolvedRefs to be False with BackendNotFound, got %+v", resolvedRefs)
+	}
+
+	// Creating the service enqueues the route, which resolves its backends again
+	api := newService("api", 8080)
+	if err := c.Create(context.Background(), api); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	requests := r.findRoutesForService(context.Background(), api)
+	if len(requests) != 1 || requests[0].NamespacedName != req.NamespacedName {
+		t.Fatalf("Expected the route to be enqueued for the api service, got %v", requests)
+	}
+	if requests := r.findRoutesForService(context.Background(), newService("other", 80)); len(requests) != 0 {
+		t.Errorf("Expected no route to be enqueued for an unrelated service, got %v", requests)
+	}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+	if err := c.Get(context.Background(), req.NamespacedName, route); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if conditions := parentConditions(t, route.Status.RouteStatus); !meta.IsStatusConditionTrue(conditions, string(gatewayv1.RouteConditionResolvedRefs)) {
+		t.Errorf("Expected ResolvedRefs to be True once the service exists, got %+v", conditions)
+	}
+}
+
+func TestHTTPRouteUnsupportedMatch(t *testing.T) {
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "regex", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "npm"}}},
+			Hostnames:       []gatewayv1.Hostname{"regex.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{
+					Type:  ptr.To(gatewayv1.PathMatchRegularExpression),
+					Value: ptr.To("/v[0-9]+"),
+				}}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: backendRef("web", 80)}},
+			}},
+		},
+	}
+
+	c, scheme := newClient(route, newService("web", 80))
+	r := &HTTPRouteReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(route)}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+
+	if err := c.Get(context.Background(), req.NamespacedName, &nginxpmoperatoriov1.ProxyHost{}); err == nil {
+		t.Error("Expected no ProxyHost for an unsupported route")
+	}
+
+	if err := c.Get(context.Background(), req.NamespacedName, route); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	accepted := meta.FindStatusCondition(parentConditions(t, route.Status.RouteStatus), string(gatewayv1.RouteConditionAccepted))
+	if accepted == nil || accepted.Status != metav1.ConditionFalse || accepted.Reason != string(gatewayv1.RouteReasonUnsupportedValue) {
+		t.Errorf("Expected Accepted to be False with UnsupportedValue, got %+v", accepted)
+	}
+}
+
+func TestStreamRouteTranslation(t *testing.T) {
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "npm"}}},
+			Rules:           []gatewayv1alpha2.TCPRouteRule{{BackendRefs: []gatewayv1.BackendRef{backendRef("postgres", 5432)}}},
+		},
+	}
+	udpRoute := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "default"},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "npm"}}},
+			Rules:           []gatewayv1alpha2.UDPRouteRule{{BackendRefs: []gatewayv1.BackendRef{backendRef("coredns", 53)}}},
+		},
+	}
+
+	c, scheme := newClient(tcpRoute, udpRoute, newService("postgres", 5432), newService("coredns", 53))
+	tcp := &TCPRouteReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	udp := &UDPRouteReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+
+	if _, err := tcp.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tcpRoute)}); err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+	if _, err := udp.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(udpRoute)}); err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+
+	// One Stream per listener of the route protocol
+	tests := []struct {
+		name     string
+		port     int
+		service  string
+		tcp, udp bool
+	}{
+		{"db-tcp-5432", 5432, "postgres", true, false},
+		{"dns-udp-53", 53, "coredns", false, true},
+	}
+
+	for _, tt := range tests {
+		st := &nginxpmoperatoriov1.Stream{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: tt.name, Namespace: "default"}, st); err != nil {
+			t.Fatalf("Expected the Stream %s to be generated: %v", tt.name, err)
+		}
+
+		if st.Spec.IncomingPort != tt.port || st.Spec.Forward.Service == nil || st.Spec.Forward.Service.Name != tt.service ||
+			st.Spec.Forward.TCPForwarding != tt.tcp || st.Spec.Forward.UDPForwarding != tt.udp {
+			t.Errorf("Unexpected spec for the Stream %s: %+v", tt.name, st.Spec)
+		}
+	}
+
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(tcpRoute), tcpRoute); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	conditions := parentConditions(t, tcpRoute.Status.RouteStatus)
+	if !meta.IsStatusConditionTrue(conditions, string(gatewayv1.RouteConditionAccepted)) ||
+		!meta.IsStatusConditionTrue(conditions, string(gatewayv1.RouteConditionResolvedRefs)) {
+		t.Errorf("Expected the TCPRoute to be accepted with resolved references, got %+v", conditions)
+	}
+
+	if requests := tcp.findRoutesForService(context.Background(), newService("postgres", 5432)); len(requests) != 1 {
+		t.Errorf("Expected the TCPRoute to be enqueued for its service, got %v", requests)
+	}
+	if requests := udp.findRoutesForService(context.Background(), newService("postgres", 5432)); len(requests) != 0 {
+		t.Errorf("Expected no UDPRoute to be enqueued for the postgres service, got %v", requests)
+	}
+}
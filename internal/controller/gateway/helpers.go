@@ -0,0 +1,386 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+)
+
+const (
+	// CONTROLLER_NAME is the GatewayClass controllerName handled by the operator
+	CONTROLLER_NAME gatewayv1.GatewayController = "nginxpm-operator.io/gateway-controller"
+
+	// ROUTE_LABEL is set on every generated object with the name of the source route
+	ROUTE_LABEL = "nginxpm-operator.io/route"
+)
+
+// routeParent is a parentRef of a route pointing to a Gateway handled by the operator
+type routeParent struct {
+	ref     gatewayv1.ParentReference
+	gateway *gatewayv1.Gateway
+
+	// listeners of the gateway the route is attached to, empty when none matches
+	listeners []gatewayv1.Listener
+}
+
+// refError is returned when a backendRef cannot be translated, reason is used in the ResolvedRefs condition
+type refError struct {
+	reason  gatewayv1.RouteConditionReason
+	message string
+}
+
+func (e *refError) Error() string {
+	return e.message
+}
+
+// resolveParents returns the parentRefs of a route which point to Gateways of a GatewayClass handled by
+// the operator, parentRefs owned by other implementations are left out.
+func resolveParents(ctx context.Context, c client.Reader, routeNamespace string, refs []gatewayv1.ParentReference, protocols ...gatewayv1.ProtocolType) ([]routeParent, error) {
+	var parents []routeParent
+
+	for _, ref := range refs {
+		if ref.Group != nil && *ref.Group != gatewayv1.GroupName {
+			continue
+		}
+
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		gateway := &gatewayv1.Gateway{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}, gateway); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		managed, err := isManagedGatewayClass(ctx, c, string(gateway.Spec.GatewayClassName))
+		if err != nil {
+			return nil, err
+		}
+
+		if !managed {
+			continue
+		}
+
+		parent := routeParent{ref: ref, gateway: gateway}
+
+		for _, listener := range gateway.Spec.Listeners {
+			if ref.SectionName != nil && *ref.SectionName != listener.Name {
+				continue
+			}
+
+			if ref.Port != nil && *ref.Port != listener.Port {
+				continue
+			}
+
+			if !protocolMatches(listener.Protocol, protocols) {
+				continue
+			}
+
+			allowed, err := routeNamespaceAllowed(ctx, c, gateway, listener, routeNamespace)
+			if err != nil {
+				return nil, err
+			}
+
+			if allowed {
+				parent.listeners = append(parent.listeners, listener)
+			}
+		}
+
+		parents = append(parents, parent)
+	}
+
+	return parents, nil
+}
+
+func isManagedGatewayClass(ctx context.Context, c client.Reader, name string) (bool, error) {
+	gatewayClass := &gatewayv1.GatewayClass{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, gatewayClass); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return gatewayClass.Spec.ControllerName == CONTROLLER_NAME, nil
+}
+
+func protocolMatches(protocol gatewayv1.ProtocolType, protocols []gatewayv1.ProtocolType) bool {
+	for _, p := range protocols {
+		if p == protocol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// routeNamespaceAllowed checks the allowedRoutes.namespaces field of the listener, routes of the
+// gateway namespace only are allowed by default
+func routeNamespaceAllowed(ctx context.Context, c client.Reader, gateway *gatewayv1.Gateway, listener gatewayv1.Listener, routeNamespace string) (bool, error) {
+	from := gatewayv1.NamespacesFromSame
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil && listener.AllowedRoutes.Namespaces.From != nil {
+		from = *listener.AllowedRoutes.Namespaces.From
+	}
+
+	switch from {
+	case gatewayv1.NamespacesFromAll:
+		return true, nil
+
+	case gatewayv1.NamespacesFromSelector:
+		if listener.AllowedRoutes.Namespaces.Selector == nil {
+			return false, nil
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(listener.AllowedRoutes.Namespaces.Selector)
+		if err != nil {
+			return false, nil
+		}
+
+		namespace := &corev1.Namespace{}
+		if err := c.Get(ctx, types.NamespacedName{Name: routeNamespace}, namespace); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		return selector.Matches(labels.Set(namespace.Labels)), nil
+
+	default:
+		return gateway.Namespace == routeNamespace, nil
+	}
+}
+
+// toForwardService converts a backendRef of a route into a ForwardService, only Service backends are supported
+func toForwardService(ctx context.Context, c client.Reader, routeKind gatewayv1.Kind, routeNamespace string, ref gatewayv1.BackendObjectReference) (*nginxpmoperatoriov1.ForwardService, error) {
+	if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Service") {
+		return nil, &refError{
+			reason:  gatewayv1.RouteReasonInvalidKind,
+			message: fmt.Sprintf("backendRef %s: only core Service backends are supported", ref.Name),
+		}
+	}
+
+	if ref.Port == nil {
+		return nil, &refError{
+			reason:  gatewayv1.RouteReasonUnsupportedValue,
+			message: fmt.Sprintf("backendRef %s: port is required for Service backends", ref.Name),
+		}
+	}
+
+	service := &nginxpmoperatoriov1.ForwardService{Name: string(ref.Name)}
+
+	port := int32(*ref.Port)
+	service.Port = &port
+
+	if ref.Namespace != nil && string(*ref.Namespace) != routeNamespace {
+		namespace := string(*ref.Namespace)
+
+		granted, err := referenceGranted(ctx, c, routeKind, routeNamespace, namespace, string(ref.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		if !granted {
+			return nil, &refError{
+				reason:  gatewayv1.RouteReasonRefNotPermitted,
+				message: fmt.Sprintf("backendRef %s/%s: not permitted by any ReferenceGrant", namespace, ref.Name),
+			}
+		}
+
+		service.Namespace = &namespace
+	}
+
+	return service, nil
+}
+
+// referenceGranted reports whether a ReferenceGrant of the Service namespace allows routes of
+// the given kind and namespace to reference the Service
+func referenceGranted(ctx context.Context, c client.Reader, routeKind gatewayv1.Kind, routeNamespace, serviceNamespace, serviceName string) (bool, error) {
+	grants := &gatewayv1beta1.ReferenceGrantList{}
+	if err := c.List(ctx, grants, client.InNamespace(serviceNamespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, grant := range grants.Items {
+		fromAllowed := false
+		for _, from := range grant.Spec.From {
+			if from.Group == gatewayv1.GroupName && from.Kind == routeKind && string(from.Namespace) == routeNamespace {
+				fromAllowed = true
+				break
+			}
+		}
+
+		if !fromAllowed {
+			continue
+		}
+
+		for _, to := range grant.Spec.To {
+			if to.Group == "" && to.Kind == "Service" && (to.Name == nil || string(*to.Name) == serviceName) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// routeToken returns the token referenced by the route annotations, falling back to the parent gateways annotations
+func routeToken(route client.Object, parents []routeParent) *nginxpmoperatoriov1.TokenName {
+	if token := controller.TokenFromAnnotations(route.GetAnnotations()); token != nil {
+		return token
+	}
+
+	for _, parent := range parents {
+		if token := controller.TokenFromAnnotations(parent.gateway.Annotations); token != nil {
+			// The token namespace defaults to the gateway namespace, not the route one
+			if token.Namespace == nil {
+				namespace := parent.gateway.Namespace
+				token.Namespace = &namespace
+			}
+			return token
+		}
+	}
+
+	return nil
+}
+
+// setParentStatuses replaces the parent statuses written by the operator, keeping the ones of other
+// implementations and the transition times of unchanged conditions
+func setParentStatuses(status *gatewayv1.RouteStatus, parents []routeParent, generation int64, accepted, resolvedRefs metav1.Condition) {
+	var statuses []gatewayv1.RouteParentStatus
+
+	for _, existing := range status.Parents {
+		if existing.ControllerName != CONTROLLER_NAME {
+			statuses = append(statuses, existing)
+		}
+	}
+
+	for _, parent := range parents {
+		parentStatus := gatewayv1.RouteParentStatus{
+			ParentRef:      parent.ref,
+			ControllerName: CONTROLLER_NAME,
+		}
+
+		for _, existing := range status.Parents {
+			if existing.ControllerName == CONTROLLER_NAME && reflect.DeepEqual(existing.ParentRef, parent.ref) {
+				parentStatus.Conditions = existing.Conditions
+				break
+			}
+		}
+
+		parentAccepted := accepted
+		if len(parent.listeners) == 0 {
+			parentAccepted = metav1.Condition{
+				Type:    string(gatewayv1.RouteConditionAccepted),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(gatewayv1.RouteReasonNoMatchingParent),
+				Message: "No listener of the gateway matches this route",
+			}
+		}
+
+		parentAccepted.ObservedGeneration = generation
+		resolvedRefs.ObservedGeneration = generation
+
+		meta.SetStatusCondition(&parentStatus.Conditions, parentAccepted)
+		meta.SetStatusCondition(&parentStatus.Conditions, resolvedRefs)
+
+		statuses = append(statuses, parentStatus)
+	}
+
+	status.Parents = statuses
+}
+
+// attachedParents returns the parents with at least one matching listener
+func attachedParents(parents []routeParent) []routeParent {
+	var attached []routeParent
+	for _, parent := range parents {
+		if len(parent.listeners) > 0 {
+			attached = append(attached, parent)
+		}
+	}
+
+	return attached
+}
+
+// referencesGateway reports whether one of the parentRefs points to the gateway
+func referencesGateway(refs []gatewayv1.ParentReference, routeNamespace string, gateway client.Object) bool {
+	for _, ref := range refs {
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		if namespace == gateway.GetNamespace() && string(ref.Name) == gateway.GetName() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// referencesService reports whether one of the backendRefs of a route points to the service
+func referencesService(refs []gatewayv1.BackendObjectReference, routeNamespace string, service client.Object) bool {
+	for _, ref := range refs {
+		if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Service") {
+			continue
+		}
+
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		if namespace == service.GetNamespace() && string(ref.Name) == service.GetName() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func condition(conditionType gatewayv1.RouteConditionType, status metav1.ConditionStatus, reason gatewayv1.RouteConditionReason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    string(conditionType),
+		Status:  status,
+		Reason:  string(reason),
+		Message: message,
+	}
+}
@@ -0,0 +1,481 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/proxyhost"
+)
+
+// Same pattern as the ProxyHost CustomLocation.LocationPath validation
+var locationPathPattern = regexp.MustCompile(`^\/([a-zA-Z0-9._~-]+\/?)*$`)
+
+// HTTPRouteReconciler translates HTTPRoute objects attached to our Gateways into ProxyHost objects
+type HTTPRouteReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=proxyhosts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile generates one ProxyHost per HTTPRoute and reports the route conditions on each parent gateway.
+func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	route := &gatewayv1.HTTPRoute{}
+
+	err := r.Get(ctx, req.NamespacedName, route)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The generated ProxyHost is garbage collected through its owner reference
+			log.Info("httproute resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get httproute")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	if !route.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	parents, err := resolveParents(ctx, r, route.Namespace, route.Spec.ParentRefs, gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType)
+	if err != nil {
+		log.Error(err, "Failed to resolve httproute parents")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	attached := attachedParents(parents)
+
+	// Not (or no more) attached to any of our gateways
+	if len(attached) == 0 {
+		if err := r.deleteProxyHost(ctx, route); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		return ctrl.Result{}, r.updateStatus(ctx, req, parents,
+			condition(gatewayv1.RouteConditionAccepted, metav1.ConditionFalse, gatewayv1.RouteReasonNoMatchingParent, "Route is not attached to any gateway listener"),
+			condition(gatewayv1.RouteConditionResolvedRefs, metav1.ConditionTrue, gatewayv1.RouteReasonResolvedRefs, "All references are resolved"),
+		)
+	}
+
+	accepted := condition(gatewayv1.RouteConditionAccepted, metav1.ConditionTrue, gatewayv1.RouteReasonAccepted, "Route is accepted")
+	resolvedRefs := condition(gatewayv1.RouteConditionResolvedRefs, metav1.ConditionTrue, gatewayv1.RouteReasonResolvedRefs, "All references are resolved")
+
+	hostnames := routeHostnames(route.Spec.Hostnames, attached)
+	if len(hostnames) == 0 {
+		accepted = condition(gatewayv1.RouteConditionAccepted, metav1.ConditionFalse, gatewayv1.RouteReasonUnsupportedValue,
+			"Nginx Proxy Manager requires at least one hostname on the route or on the gateway listener")
+
+		return ctrl.Result{}, r.updateStatus(ctx, req, parents, accepted, resolvedRefs)
+	}
+
+	spec, err := r.buildProxyHostSpec(ctx, route, hostnames, routeToken(route, attached))
+	if err != nil {
+		var refErr *refError
+		if !errors.As(err, &refErr) {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		// Leave the generated ProxyHost as is, the route must be fixed first
+		r.Recorder.Event(
+			route, "Warning", "BuildProxyHost",
+			fmt.Sprintf("Failed to build proxy host, ResourceName: %s, Namespace: %s, err: %s",
+				req.Name, req.Namespace, err.Error()),
+		)
+
+		if refErr.reason == gatewayv1.RouteReasonUnsupportedValue {
+			accepted = condition(gatewayv1.RouteConditionAccepted, metav1.ConditionFalse, refErr.reason, refErr.message)
+		} else {
+			resolvedRefs = condition(gatewayv1.RouteConditionResolvedRefs, metav1.ConditionFalse, refErr.reason, refErr.message)
+		}
+
+		return ctrl.Result{}, r.updateStatus(ctx, req, parents, accepted, resolvedRefs)
+	}
+
+	// The ProxyHost is applied even when its services are missing, it will be reconciled once they exist
+	if err := r.resolveBackends(ctx, req, route, spec); err != nil {
+		resolvedRefs = condition(gatewayv1.RouteConditionResolvedRefs, metav1.ConditionFalse, gatewayv1.RouteReasonBackendNotFound, err.Error())
+	}
+
+	if err := r.applyProxyHost(ctx, route, spec); err != nil {
+		r.Recorder.Event(
+			route, "Warning", "ApplyProxyHost",
+			fmt.Sprintf("Failed to apply proxy host, ResourceName: %s, Namespace: %s, err: %s",
+				req.Name, req.Namespace, err.Error()),
+		)
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	return ctrl.Result{}, r.updateStatus(ctx, req, parents, accepted, resolvedRefs)
+}
+
+func (r *HTTPRouteReconciler) buildProxyHostSpec(ctx context.Context, route *gatewayv1.HTTPRoute, hostnames []string, token *nginxpmoperatoriov1.TokenName) (*nginxpmoperatoriov1.ProxyHostSpec, error) {
+	var forward *nginxpmoperatoriov1.ProxyHostForward
+	var customLocations []nginxpmoperatoriov1.CustomLocation
+
+	locations := map[string]bool{}
+
+	for _, rule := range route.Spec.Rules {
+		backendRef := firstBackendRef(rule.BackendRefs)
+		if backendRef == nil {
+			continue
+		}
+
+		service, err := toForwardService(ctx, r, "HTTPRoute", route.Namespace, backendRef.BackendObjectReference)
+		if err != nil {
+			return nil, err
+		}
+
+		ruleForward := nginxpmoperatoriov1.ProxyHostForward{
+			Scheme:         "http",
+			Service:        service,
+			NginxKeepalive: true,
+		}
+
+		// Nginx Proxy Manager locations are prefix matches, header, query and method matches are ignored
+		paths := []string{"/"}
+		if len(rule.Matches) > 0 {
+			paths = nil
+			for _, match := range rule.Matches {
+				path, err := matchPath(match)
+				if err != nil {
+					return nil, err
+				}
+				paths = append(paths, path)
+			}
+		}
+
+		for _, path := range paths {
+			if path == "/" {
+				if forward == nil {
+					forward = ruleForward.DeepCopy()
+				}
+				continue
+			}
+
+			// Rules are evaluated in order, the first one wins
+			if locations[path] {
+				continue
+			}
+			locations[path] = true
+
+			customLocations = append(customLocations, nginxpmoperatoriov1.CustomLocation{
+				LocationPath: path,
+				Forward:      *ruleForward.DeepCopy(),
+			})
+		}
+	}
+
+	// Without root match, the first location is also used as main forward
+	if forward == nil && len(customLocations) > 0 {
+		forward = customLocations[0].Forward.DeepCopy()
+	}
+
+	if forward == nil {
+		return nil, &refError{
+			reason:  gatewayv1.RouteReasonUnsupportedValue,
+			message: "route has no rule with a backendRef",
+		}
+	}
+
+	domainNames := make([]nginxpmoperatoriov1.DomainName, len(hostnames))
+	for i, hostname := range hostnames {
+		domainNames[i] = nginxpmoperatoriov1.DomainName(hostname)
+	}
+
+	return &nginxpmoperatoriov1.ProxyHostSpec{
+		Token:            token,
+		DomainNames:      domainNames,
+		BindExisting:     true,
+		BlockExploits:    true,
+		WebsocketSupport: true,
+		Forward:          *forward,
+		CustomLocations:  customLocations,
+	}, nil
+}
+
+// resolveBackends checks that the services of the generated ProxyHost can be forwarded to,
+// using the same resolution as the ProxyHost controller
+func (r *HTTPRouteReconciler) resolveBackends(ctx context.Context, req ctrl.Request, route *gatewayv1.HTTPRoute, spec *nginxpmoperatoriov1.ProxyHostSpec) error {
+	ph := &nginxpmoperatoriov1.ProxyHost{
+		ObjectMeta: metav1.ObjectMeta{Name: route.Name, Namespace: route.Namespace},
+		Spec:       *spec,
+	}
+
+	proxyHostReconciler := &proxyhost.ProxyHostReconciler{Client: r.Client, Scheme: r.Scheme, Recorder: r.Recorder}
+
+	upstreamForward, err := proxyHostReconciler.MakeForward(proxyhost.MakeForwardOption{
+		Ctx:       ctx,
+		Req:       req,
+		ProxyHost: ph,
+		Forward:   spec.Forward,
+		Label:     "upstream-forward",
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, location := range spec.CustomLocations {
+		_, err := proxyHostReconciler.MakeForward(proxyhost.MakeForwardOption{
+			Ctx:             ctx,
+			Req:             req,
+			ProxyHost:       ph,
+			UpstreamForward: upstreamForward,
+			Forward:         location.Forward,
+			Label:           fmt.Sprintf("downstream-forward-%d", i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *HTTPRouteReconciler) applyProxyHost(ctx context.Context, route *gatewayv1.HTTPRoute, spec *nginxpmoperatoriov1.ProxyHostSpec) error {
+	ph := &nginxpmoperatoriov1.ProxyHost{
+		ObjectMeta: metav1.ObjectMeta{Name: route.Name, Namespace: route.Namespace},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, ph, func() error {
+		// Never take over a ProxyHost created by hand
+		if !ph.CreationTimestamp.IsZero() && !metav1.IsControlledBy(ph, route) {
+			return fmt.Errorf("proxyhost %s already exists and is not managed by this route", ph.Name)
+		}
+
+		if ph.Labels == nil {
+			ph.Labels = map[string]string{}
+		}
+		ph.Labels[ROUTE_LABEL] = route.Name
+		ph.Spec = *spec
+
+		return controllerutil.SetControllerReference(route, ph, r.Scheme)
+	})
+
+	return err
+}
+
+func (r *HTTPRouteReconciler) deleteProxyHost(ctx context.Context, route *gatewayv1.HTTPRoute) error {
+	ph := &nginxpmoperatoriov1.ProxyHost{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: route.Namespace, Name: route.Name}, ph); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !metav1.IsControlledBy(ph, route) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Deleting ProxyHost of detached httproute", "ProxyHost", ph.Name)
+
+	return client.IgnoreNotFound(r.Delete(ctx, ph))
+}
+
+func (r *HTTPRouteReconciler) updateStatus(ctx context.Context, req ctrl.Request, parents []routeParent, accepted, resolvedRefs metav1.Condition) error {
+	route := &gatewayv1.HTTPRoute{}
+
+	return controller.UpdateStatus(ctx, r.Client, route, req.NamespacedName, func() {
+		setParentStatuses(&route.Status.RouteStatus, parents, route.Generation, accepted, resolvedRefs)
+	})
+}
+
+// ############################################# UTILS ##############################################
+
+// routeHostnames returns the route hostnames accepted by at least one listener,
+// or the listeners hostnames when the route does not define any
+func routeHostnames(hostnames []gatewayv1.Hostname, parents []routeParent) []string {
+	var result []string
+	seen := map[string]bool{}
+
+	add := func(hostname string) {
+		if !seen[hostname] {
+			seen[hostname] = true
+			result = append(result, hostname)
+		}
+	}
+
+	for _, parent := range parents {
+		for _, listener := range parent.listeners {
+			if len(hostnames) == 0 {
+				if listener.Hostname != nil {
+					add(string(*listener.Hostname))
+				}
+				continue
+			}
+
+			for _, hostname := range hostnames {
+				if hostnameMatches(listener.Hostname, string(hostname)) {
+					add(string(hostname))
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func hostnameMatches(listenerHostname *gatewayv1.Hostname, hostname string) bool {
+	if listenerHostname == nil || string(*listenerHostname) == hostname {
+		return true
+	}
+
+	listener := string(*listenerHostname)
+	if strings.HasPrefix(listener, "*.") {
+		return strings.HasSuffix(hostname, listener[1:]) && !strings.HasPrefix(hostname, "*.")
+	}
+
+	return false
+}
+
+func matchPath(match gatewayv1.HTTPRouteMatch) (string, error) {
+	if match.Path == nil || match.Path.Value == nil {
+		return "/", nil
+	}
+
+	if match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression {
+		return "", &refError{
+			reason:  gatewayv1.RouteReasonUnsupportedValue,
+			message: fmt.Sprintf("path %s: regular expression matches are not supported", *match.Path.Value),
+		}
+	}
+
+	path := *match.Path.Value
+	if path == "/" {
+		return path, nil
+	}
+
+	if !locationPathPattern.MatchString(path) {
+		return "", &refError{
+			reason:  gatewayv1.RouteReasonUnsupportedValue,
+			message: fmt.Sprintf("path %s is not supported as a custom location", path),
+		}
+	}
+
+	return path, nil
+}
+
+// firstBackendRef returns the first backend receiving traffic, Nginx Proxy Manager has no traffic splitting
+func firstBackendRef(backendRefs []gatewayv1.HTTPBackendRef) *gatewayv1.HTTPBackendRef {
+	for i, backendRef := range backendRefs {
+		if backendRef.Weight == nil || *backendRef.Weight > 0 {
+			return &backendRefs[i]
+		}
+	}
+
+	return nil
+}
+
+// ############################################# CONTROLLER ##############################################
+
+// findRoutesForGateway enqueues the routes attached to a gateway when it changes
+func (r *HTTPRouteReconciler) findRoutesForGateway(ctx context.Context, gateway client.Object) []reconcile.Request {
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := r.List(ctx, routes); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, route := range routes.Items {
+		if referencesGateway(route.Spec.ParentRefs, route.Namespace, gateway) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&route),
+			})
+		}
+	}
+
+	return requests
+}
+
+// findRoutesForService enqueues the routes forwarding to a service when it changes,
+// so their ResolvedRefs condition follows the service being created or deleted
+func (r *HTTPRouteReconciler) findRoutesForService(ctx context.Context, service client.Object) []reconcile.Request {
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := r.List(ctx, routes); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, route := range routes.Items {
+		var refs []gatewayv1.BackendObjectReference
+		for _, rule := range route.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				refs = append(refs, backendRef.BackendObjectReference)
+			}
+		}
+
+		if referencesService(refs, route.Namespace, service) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&route),
+			})
+		}
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1.HTTPRoute{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Owns(&nginxpmoperatoriov1.ProxyHost{}).
+		Watches(
+			&gatewayv1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForGateway),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForService),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Named("httproute").
+		Complete(r)
+}
@@ -0,0 +1,449 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller/stream"
+)
+
+// streamRoute holds the fields shared by TCPRoute and UDPRoute
+type streamRoute struct {
+	object     client.Object
+	kind       gatewayv1.Kind
+	protocol   gatewayv1.ProtocolType
+	parentRefs []gatewayv1.ParentReference
+	rules      [][]gatewayv1.BackendRef
+	status     *gatewayv1.RouteStatus
+}
+
+func newTCPRoute(route *gatewayv1alpha2.TCPRoute) *streamRoute {
+	rules := make([][]gatewayv1.BackendRef, len(route.Spec.Rules))
+	for i, rule := range route.Spec.Rules {
+		rules[i] = rule.BackendRefs
+	}
+
+	return &streamRoute{
+		object:     route,
+		kind:       "TCPRoute",
+		protocol:   gatewayv1.TCPProtocolType,
+		parentRefs: route.Spec.ParentRefs,
+		rules:      rules,
+		status:     &route.Status.RouteStatus,
+	}
+}
+
+func newUDPRoute(route *gatewayv1alpha2.UDPRoute) *streamRoute {
+	rules := make([][]gatewayv1.BackendRef, len(route.Spec.Rules))
+	for i, rule := range route.Spec.Rules {
+		rules[i] = rule.BackendRefs
+	}
+
+	return &streamRoute{
+		object:     route,
+		kind:       "UDPRoute",
+		protocol:   gatewayv1.UDPProtocolType,
+		parentRefs: route.Spec.ParentRefs,
+		rules:      rules,
+		status:     &route.Status.RouteStatus,
+	}
+}
+
+// TCPRouteReconciler translates TCPRoute objects attached to our Gateways into Stream objects
+type TCPRouteReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=streams,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile generates one Stream per TCP listener the TCPRoute is attached to.
+func (r *TCPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	route := &gatewayv1alpha2.TCPRoute{}
+
+	return reconcileStreamRoute(ctx, req, r.Client, r.Scheme, r.Recorder, route, func() *streamRoute {
+		return newTCPRoute(route)
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TCPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha2.TCPRoute{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Owns(&nginxpmoperatoriov1.Stream{}).
+		Watches(
+			&gatewayv1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForGateway),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForService),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Named("tcproute").
+		Complete(r)
+}
+
+func (r *TCPRouteReconciler) findRoutesForGateway(ctx context.Context, gateway client.Object) []reconcile.Request {
+	routes := &gatewayv1alpha2.TCPRouteList{}
+	if err := r.List(ctx, routes); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, route := range routes.Items {
+		if referencesGateway(route.Spec.ParentRefs, route.Namespace, gateway) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&route)})
+		}
+	}
+
+	return requests
+}
+
+// findRoutesForService enqueues the routes forwarding to a service when it changes
+func (r *TCPRouteReconciler) findRoutesForService(ctx context.Context, service client.Object) []reconcile.Request {
+	routes := &gatewayv1alpha2.TCPRouteList{}
+	if err := r.List(ctx, routes); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, route := range routes.Items {
+		if referencesService(streamRouteBackendRefs(newTCPRoute(&route)), route.Namespace, service) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&route)})
+		}
+	}
+
+	return requests
+}
+
+// UDPRouteReconciler translates UDPRoute objects attached to our Gateways into Stream objects
+type UDPRouteReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=udproutes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=udproutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=streams,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile generates one Stream per UDP listener the UDPRoute is attached to.
+func (r *UDPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	route := &gatewayv1alpha2.UDPRoute{}
+
+	return reconcileStreamRoute(ctx, req, r.Client, r.Scheme, r.Recorder, route, func() *streamRoute {
+		return newUDPRoute(route)
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *UDPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1alpha2.UDPRoute{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Owns(&nginxpmoperatoriov1.Stream{}).
+		Watches(
+			&gatewayv1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForGateway),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForService),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Named("udproute").
+		Complete(r)
+}
+
+func (r *UDPRouteReconciler) findRoutesForGateway(ctx context.Context, gateway client.Object) []reconcile.Request {
+	routes := &gatewayv1alpha2.UDPRouteList{}
+	if err := r.List(ctx, routes); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, route := range routes.Items {
+		if referencesGateway(route.Spec.ParentRefs, route.Namespace, gateway) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&route)})
+		}
+	}
+
+	return requests
+}
+
+// findRoutesForService enqueues the routes forwarding to a service when it changes
+func (r *UDPRouteReconciler) findRoutesForService(ctx context.Context, service client.Object) []reconcile.Request {
+	routes := &gatewayv1alpha2.UDPRouteList{}
+	if err := r.List(ctx, routes); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, route := range routes.Items {
+		if referencesService(streamRouteBackendRefs(newUDPRoute(&route)), route.Namespace, service) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&route)})
+		}
+	}
+
+	return requests
+}
+
+// ############################################# STREAM ROUTES ##############################################
+
+// reconcileStreamRoute generates one Stream per listener port the route is attached to, object is
+// fetched in place so that view keeps reading the up to date fields
+func reconcileStreamRoute(ctx context.Context, req ctrl.Request, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, object client.Object, view func() *streamRoute) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	err := c.Get(ctx, req.NamespacedName, object)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The generated Streams are garbage collected through their owner reference
+			log.Info("route resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get route")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	if !object.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	route := view()
+
+	parents, err := resolveParents(ctx, c, object.GetNamespace(), route.parentRefs, route.protocol)
+	if err != nil {
+		log.Error(err, "Failed to resolve route parents")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	attached := attachedParents(parents)
+
+	accepted := condition(gatewayv1.RouteConditionAccepted, metav1.ConditionTrue, gatewayv1.RouteReasonAccepted, "Route is accepted")
+	resolvedRefs := condition(gatewayv1.RouteConditionResolvedRefs, metav1.ConditionTrue, gatewayv1.RouteReasonResolvedRefs, "All references are resolved")
+
+	updateStatus := func() error {
+		return controller.UpdateStatus(ctx, c, object, req.NamespacedName, func() {
+			setParentStatuses(view().status, parents, object.GetGeneration(), accepted, resolvedRefs)
+		})
+	}
+
+	keep := map[string]bool{}
+
+	if len(attached) == 0 {
+		accepted = condition(gatewayv1.RouteConditionAccepted, metav1.ConditionFalse, gatewayv1.RouteReasonNoMatchingParent, "Route is not attached to any gateway listener")
+
+		if err := deleteStaleStreams(ctx, c, object, keep); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		return ctrl.Result{}, updateStatus()
+	}
+
+	forward, err := streamRouteForward(ctx, c, route)
+	if err != nil {
+		var refErr *refError
+		if !errors.As(err, &refErr) {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		// Leave the generated Streams as they are, the route must be fixed first
+		recorder.Event(
+			object, "Warning", "BuildStream",
+			fmt.Sprintf("Failed to build stream, ResourceName: %s, Namespace: %s, err: %s",
+				req.Name, req.Namespace, err.Error()),
+		)
+
+		if refErr.reason == gatewayv1.RouteReasonUnsupportedValue {
+			accepted = condition(gatewayv1.RouteConditionAccepted, metav1.ConditionFalse, refErr.reason, refErr.message)
+		} else {
+			resolvedRefs = condition(gatewayv1.RouteConditionResolvedRefs, metav1.ConditionFalse, refErr.reason, refErr.message)
+		}
+
+		return ctrl.Result{}, updateStatus()
+	}
+
+	token := routeToken(object, attached)
+	streamReconciler := &stream.StreamReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+	for _, parent := range attached {
+		for _, listener := range parent.listeners {
+			name := controller.GeneratedName(object.GetName(), string(route.protocol), strconv.Itoa(int(listener.Port)))
+			if keep[name] {
+				continue
+			}
+
+			st := &nginxpmoperatoriov1.Stream{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: object.GetNamespace()},
+				Spec: nginxpmoperatoriov1.StreamSpec{
+					Token:        token,
+					IncomingPort: int(listener.Port),
+					Forward:      *forward.DeepCopy(),
+				},
+			}
+
+			// The Stream is applied even when its service is missing, it will be reconciled once it exists
+			_, err := streamReconciler.MakeForward(stream.MakeForwardOption{
+				Ctx:    ctx,
+				Req:    req,
+				Stream: st,
+			})
+			if err != nil {
+				resolvedRefs = condition(gatewayv1.RouteConditionResolvedRefs, metav1.ConditionFalse, gatewayv1.RouteReasonBackendNotFound, err.Error())
+			}
+
+			if err := applyStream(ctx, c, scheme, object, st); err != nil {
+				recorder.Event(
+					object, "Warning", "ApplyStream",
+					fmt.Sprintf("Failed to apply stream, ResourceName: %s, Namespace: %s, err: %s",
+						name, req.Namespace, err.Error()),
+				)
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+			keep[name] = true
+		}
+	}
+
+	if err := deleteStaleStreams(ctx, c, object, keep); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	return ctrl.Result{}, updateStatus()
+}
+
+// streamRouteBackendRefs returns the backendRefs of all the rules of the route
+func streamRouteBackendRefs(route *streamRoute) []gatewayv1.BackendObjectReference {
+	var refs []gatewayv1.BackendObjectReference
+	for _, backendRefs := range route.rules {
+		for _, backendRef := range backendRefs {
+			refs = append(refs, backendRef.BackendObjectReference)
+		}
+	}
+
+	return refs
+}
+
+// streamRouteForward builds the stream forward from the first backendRef of the route,
+// Nginx Proxy Manager streams have a single upstream
+func streamRouteForward(ctx context.Context, c client.Reader, route *streamRoute) (*nginxpmoperatoriov1.StreamForward, error) {
+	for _, backendRefs := range route.rules {
+		for _, backendRef := range backendRefs {
+			if backendRef.Weight != nil && *backendRef.Weight == 0 {
+				continue
+			}
+
+			service, err := toForwardService(ctx, c, route.kind, route.object.GetNamespace(), backendRef.BackendObjectReference)
+			if err != nil {
+				return nil, err
+			}
+
+			return &nginxpmoperatoriov1.StreamForward{
+				TCPForwarding: route.protocol == gatewayv1.TCPProtocolType,
+				UDPForwarding: route.protocol == gatewayv1.UDPProtocolType,
+				Service:       service,
+			}, nil
+		}
+	}
+
+	return nil, &refError{
+		reason:  gatewayv1.RouteReasonUnsupportedValue,
+		message: "route has no rule with a backendRef",
+	}
+}
+
+func applyStream(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object, desired *nginxpmoperatoriov1.Stream) error {
+	st := &nginxpmoperatoriov1.Stream{
+		ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, c, st, func() error {
+		// Never take over a Stream created by hand
+		if !st.CreationTimestamp.IsZero() && !metav1.IsControlledBy(st, owner) {
+			return fmt.Errorf("stream %s already exists and is not managed by this route", st.Name)
+		}
+
+		if st.Labels == nil {
+			st.Labels = map[string]string{}
+		}
+		st.Labels[ROUTE_LABEL] = owner.GetName()
+		st.Spec = desired.Spec
+
+		return controllerutil.SetControllerReference(owner, st, scheme)
+	})
+
+	return err
+}
+
+// deleteStaleStreams removes the streams generated for the route which are not part of keep
+func deleteStaleStreams(ctx context.Context, c client.Client, owner client.Object, keep map[string]bool) error {
+	log := log.FromContext(ctx)
+
+	streams := &nginxpmoperatoriov1.StreamList{}
+	if err := c.List(ctx, streams, client.InNamespace(owner.GetNamespace()), client.MatchingLabels{ROUTE_LABEL: owner.GetName()}); err != nil {
+		return err
+	}
+
+	for _, st := range streams.Items {
+		if keep[st.Name] || !metav1.IsControlledBy(&st, owner) {
+			continue
+		}
+
+		log.Info("Deleting stale Stream generated from route", "Stream", st.Name)
+		if err := c.Delete(ctx, &st); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -19,13 +19,18 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"os"
 	"reflect"
 	"strings"
 	"time"
 
 	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
 	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
-	"github.com/paradoxe35/nginxpm-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +48,21 @@ const (
 
 	// ConditionTypeError indicates if there's an error with the Resource
 	ConditionTypeError = "Error"
+
+	// ConditionTypeDrifted indicates if the Resource was changed in Nginx Proxy Manager outside of the operator
+	ConditionTypeDrifted = "Drifted"
+
+	// ConditionTypeSuspended indicates if the reconciliation of the Resource is suspended
+	ConditionTypeSuspended = "Suspended"
+
+	// ConditionTypeInUse indicates if the Resource is referenced by other resources
+	ConditionTypeInUse = "InUse"
+
+	// ConditionTypeRenewing indicates if the certificate of the Resource is being renewed
+	ConditionTypeRenewing = "Renewing"
+
+	// ConditionTypeRenewalFailed indicates if the last renewal of the certificate of the Resource failed
+	ConditionTypeRenewalFailed = "RenewalFailed"
 )
 
 const (
@@ -52,9 +72,70 @@ const (
 	// when no specific namespace is provided
 	TOKEN_SYSTEM_NAMESPACE  = "nginxpm-operator-system"
 	TOKEN_DEFAULT_NAMESPACE = "default"
+
+	// TOKEN_ANNOTATION selects the Token of generated resources, as "name" or "namespace/name"
+	TOKEN_ANNOTATION = "nginxpm-operator.io/token"
+
+	// CLUSTER_TOKEN_ANNOTATION selects a ClusterToken by name for generated resources,
+	// it takes precedence over TOKEN_ANNOTATION
+	CLUSTER_TOKEN_ANNOTATION = "nginxpm-operator.io/cluster-token"
+
+	// Kinds of resources a TokenName can reference
+	TOKEN_KIND         = "Token"
+	CLUSTER_TOKEN_KIND = "ClusterToken"
+
+	// OPERATOR_NAMESPACE_ENV holds the namespace the operator runs in,
+	// where the secrets of ClusterToken resources are looked up
+	OPERATOR_NAMESPACE_ENV = "OPERATOR_NAMESPACE"
 )
 
-func InitNginxPMClient(ctx context.Context, r client.Reader, req reconcile.Request, tokenName *nginxpmoperatoriov1.TokenName) (*nginxpm.Client, error) {
+// OperatorNamespace returns the namespace the operator runs in
+func OperatorNamespace() string {
+	if namespace := os.Getenv(OPERATOR_NAMESPACE_ENV); namespace != "" {
+		return namespace
+	}
+
+	return TOKEN_SYSTEM_NAMESPACE
+}
+
+// IsClusterTokenRef reports whether tokenName references a ClusterToken
+func IsClusterTokenRef(tokenName *nginxpmoperatoriov1.TokenName) bool {
+	return tokenName != nil && tokenName.Kind == CLUSTER_TOKEN_KIND
+}
+
+// TokenFromAnnotations returns the token referenced by the CLUSTER_TOKEN_ANNOTATION
+// or TOKEN_ANNOTATION annotations, if any
+func TokenFromAnnotations(annotations map[string]string) *nginxpmoperatoriov1.TokenName {
+	if name := strings.TrimSpace(annotations[CLUSTER_TOKEN_ANNOTATION]); name != "" {
+		return &nginxpmoperatoriov1.TokenName{Kind: CLUSTER_TOKEN_KIND, Name: name}
+	}
+
+	value := strings.TrimSpace(annotations[TOKEN_ANNOTATION])
+	if value == "" {
+		return nil
+	}
+
+	if namespace, name, found := strings.Cut(value, "/"); found {
+		return &nginxpmoperatoriov1.TokenName{Name: name, Namespace: &namespace}
+	}
+
+	return &nginxpmoperatoriov1.TokenName{Name: value}
+}
+
+// GeneratedName joins parts into the lower case name of a generated resource,
+// cut to the maximum length of a resource name
+func GeneratedName(parts ...string) string {
+	name := strings.ToLower(strings.Join(parts, "-"))
+	if len(name) > 253 {
+		name = name[:253]
+	}
+
+	return strings.TrimRight(name, "-.")
+}
+
+// FindToken looks up the Token resource referenced by tokenName, falling back to
+// the default token name and namespaces when it is not found.
+func FindToken(ctx context.Context, r client.Reader, req reconcile.Request, tokenName *nginxpmoperatoriov1.TokenName) (*nginxpmoperatoriov1.Token, error) {
 	log := log.FromContext(ctx)
 
 	// Set the token names
@@ -71,10 +152,7 @@ func InitNginxPMClient(ctx context.Context, r client.Reader, req reconcile.Reque
 		namespaces = append([]string{*tokenName.Namespace}, namespaces...)
 	}
 
-	token := &nginxpmoperatoriov1.Token{}
-
 	for _, namespace := range namespaces {
-		found := false
 		for _, name := range names {
 			tokenNamespaced := types.NamespacedName{
 				Namespace: namespace,
@@ -82,39 +160,127 @@ func InitNginxPMClient(ctx context.Context, r client.Reader, req reconcile.Reque
 			}
 
 			// Get the token resource
-			err := r.Get(ctx, tokenNamespaced, token)
-			if err == nil {
+			token := &nginxpmoperatoriov1.Token{}
+			if err := r.Get(ctx, tokenNamespaced, token); err == nil {
 				log.Info("Token resource found", "Namespace", namespace, "Name", name)
-				found = true
-				break
+				return token, nil
 			}
 		}
+	}
 
-		// token found on this iteration
-		if found {
-			break
-		}
+	err := errors.New("token resource not found")
+	log.Error(err, "Token resource not found")
+	return nil, err
+}
+
+// FindClusterToken looks up the ClusterToken resource referenced by tokenName and makes sure
+// its allowedNamespaces selector matches the namespace of the request.
+func FindClusterToken(ctx context.Context, r client.Reader, req reconcile.Request, tokenName *nginxpmoperatoriov1.TokenName) (*nginxpmoperatoriov1.ClusterToken, error) {
+	log := log.FromContext(ctx)
+
+	if tokenName == nil || len(tokenName.Name) == 0 {
+		return nil, errors.New("cluster token name is required")
 	}
 
-	// If token still empty, means it was not found
-	if token.Name == "" || token.Status.Token == nil {
-		err := errors.New("token resource not found")
-		log.Error(err, "Token resource not found")
+	clusterToken := &nginxpmoperatoriov1.ClusterToken{}
+	if err := r.Get(ctx, types.NamespacedName{Name: tokenName.Name}, clusterToken); err != nil {
+		log.Error(err, "ClusterToken resource not found", "Name", tokenName.Name)
 		return nil, err
 	}
 
-	// Create a new Nginx Proxy Manager client
-	nginxpmClient := nginxpm.NewClientFromToken(util.NewHttpClient(), token)
+	// An unset selector matches no namespace, an empty one matches all of them
+	selector, err := metav1.LabelSelectorAsSelector(clusterToken.Spec.AllowedNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowedNamespaces of cluster token %s: %w", clusterToken.Name, err)
+	}
 
-	// Check if the connection is established
-	if err := nginxpmClient.CheckTokenAccess(); err != nil {
-		log.Error(err, "Token access check failed")
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: req.Namespace}, namespace); err != nil {
 		return nil, err
 	}
 
+	if !selector.Matches(labels.Set(namespace.Labels)) {
+		err := fmt.Errorf("cluster token %s is not allowed in namespace %s", clusterToken.Name, req.Namespace)
+		log.Error(err, "ClusterToken resource not allowed")
+		return nil, err
+	}
+
+	log.Info("ClusterToken resource found", "Name", clusterToken.Name)
+
+	return clusterToken, nil
+}
+
+// GetTokenSecret returns the Secret holding the JWT issued for a Token or ClusterToken resource
+func GetTokenSecret(ctx context.Context, r client.Reader, status nginxpmoperatoriov1.TokenStatus) (*corev1.Secret, error) {
+	if status.TokenSecretRef == nil {
+		return nil, errors.New("token has not been issued yet")
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: status.TokenSecretRef.Namespace, Name: status.TokenSecretRef.Name}
+	if err := r.Get(ctx, secretKey, secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// TokenRef returns the reference of a Token resource, as surfaced in the status.tokenRef field
+func TokenRef(token *nginxpmoperatoriov1.Token) *nginxpmoperatoriov1.TokenName {
+	namespace := token.Namespace
+	return &nginxpmoperatoriov1.TokenName{Name: token.Name, Namespace: &namespace}
+}
+
+// ClusterTokenRef returns the reference of a ClusterToken resource
+func ClusterTokenRef(clusterToken *nginxpmoperatoriov1.ClusterToken) *nginxpmoperatoriov1.TokenName {
+	return &nginxpmoperatoriov1.TokenName{Kind: CLUSTER_TOKEN_KIND, Name: clusterToken.Name}
+}
+
+// InitNginxPMClient returns the pooled client for the Nginx Proxy Manager instance of the Token or ClusterToken
+// referenced by tokenName and the reference of the token which was actually used.
+func InitNginxPMClient(ctx context.Context, r client.Reader, req reconcile.Request, tokenName *nginxpmoperatoriov1.TokenName) (*nginxpm.Client, *nginxpmoperatoriov1.TokenName, error) {
+	log := log.FromContext(ctx)
+
+	var (
+		tokenObject client.Object
+		endpoint    string
+		status      nginxpmoperatoriov1.TokenStatus
+		tokenRef    *nginxpmoperatoriov1.TokenName
+	)
+
+	if IsClusterTokenRef(tokenName) {
+		clusterToken, err := FindClusterToken(ctx, r, req, tokenName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tokenObject, endpoint, status, tokenRef = clusterToken, clusterToken.Spec.Endpoint, clusterToken.Status, ClusterTokenRef(clusterToken)
+	} else {
+		token, err := FindToken(ctx, r, req, tokenName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tokenObject, endpoint, status, tokenRef = token, token.Spec.Endpoint, token.Status, TokenRef(token)
+	}
+
+	nginxpmClient, err := ClientPool.Get(ctx, tokenObject, func(httpClient *http.Client) (*nginxpm.Client, error) {
+		// The token resource exists but its secret has not been written yet
+		tokenSecret, err := GetTokenSecret(ctx, r, status)
+		if err != nil {
+			return nil, err
+		}
+
+		return nginxpm.NewClientFromTokenStatus(httpClient, endpoint, status, tokenSecret), nil
+	})
+	if err != nil {
+		log.Error(err, "Failed to get NginxPM client")
+		return nil, nil, err
+	}
+
 	log.Info("NginxPM client initialized successfully")
 
-	return nginxpmClient, nil
+	return nginxpmClient, tokenRef, nil
 }
 
 // RemoveFinalizer will remove the finalizer from the object
@@ -149,6 +315,18 @@ func AddFinalizer(r client.Writer, ctx context.Context, finalizer string, object
 	return nil
 }
 
+// ResultForNginxPMError returns the result of a reconcile failed by a Nginx Proxy Manager call.
+// The error is returned so the failure shows up in the reconcile metrics and the resource is requeued
+// with the rate limited backoff of the controller. A rejected token also drops the pooled client,
+// so the next attempt builds a new one.
+func ResultForNginxPMError(nginxpmClient *nginxpm.Client, err error) (reconcile.Result, error) {
+	if nginxpm.IsUnauthorized(err) && nginxpmClient != nil {
+		ClientPool.InvalidateClient(nginxpmClient)
+	}
+
+	return reconcile.Result{}, err
+}
+
 // Custom retry backoff for status updates
 var statusUpdateRetry = retry.DefaultRetry
 
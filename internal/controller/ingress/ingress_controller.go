@@ -0,0 +1,513 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+)
+
+const (
+	// DEFAULT_INGRESS_CLASS is the ingressClassName handled when none is configured
+	DEFAULT_INGRESS_CLASS = "nginxpm"
+
+	// INGRESS_LABEL is set on every generated object with the name of the source Ingress
+	INGRESS_LABEL = "nginxpm-operator.io/ingress"
+
+	// INGRESS_CLASS_ANNOTATION is the legacy way of selecting an ingress class
+	INGRESS_CLASS_ANNOTATION = "kubernetes.io/ingress.class"
+
+	// INGRESS_BACKEND_PROTOCOL_ANNOTATION sets the forward scheme of generated ProxyHosts (http or https)
+	INGRESS_BACKEND_PROTOCOL_ANNOTATION = "nginxpm-operator.io/backend-protocol"
+)
+
+// Same pattern as the ProxyHost CustomLocation.LocationPath validation
+var locationPathPattern = regexp.MustCompile(`^\/([a-zA-Z0-9._~-]+\/?)*$`)
+
+// IngressReconciler translates Ingress objects into ProxyHost objects
+type IngressReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// IngressClassName is the class of Ingress objects handled by this reconciler
+	IngressClassName string
+}
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=proxyhosts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=customcertificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile generates one ProxyHost per Ingress rule host and one CustomCertificate
+// per Ingress tls secret, then publishes the NPM endpoint in the Ingress status.
+func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	ing := &networkingv1.Ingress{}
+
+	err := r.Get(ctx, req.NamespacedName, ing)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Generated objects are garbage collected through their owner reference
+			log.Info("ingress resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get ingress")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	if !ing.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// The ingress may have been moved to another class, drop what we generated for it
+	if !r.isManaged(ing) {
+		return ctrl.Result{}, r.deleteStaleObjects(ctx, ing, map[string]bool{})
+	}
+
+	token := controller.TokenFromAnnotations(ing.Annotations)
+
+	// Keep track of the generated objects, anything else owned by the ingress is stale
+	keep := map[string]bool{}
+
+	// TLS secrets become CustomCertificate objects
+	certificates := map[string]string{}
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+
+		name := controller.GeneratedName(ing.Name, "tls", tls.SecretName)
+		if err := r.applyCustomCertificate(ctx, ing, name, tls.SecretName, token); err != nil {
+			r.Recorder.Event(
+				ing, "Warning", "ApplyCustomCertificate",
+				fmt.Sprintf("Failed to apply custom certificate, ResourceName: %s, Namespace: %s, err: %s",
+					name, req.Namespace, err.Error()),
+			)
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		keep[customCertificateKey(name)] = true
+
+		for _, host := range tls.Hosts {
+			certificates[host] = name
+		}
+	}
+
+	// Rules become ProxyHost objects, one per host, the paths of the rules sharing a host are merged
+	rules, duplicates := mergeRulesByHost(ing.Spec.Rules)
+	for _, duplicate := range duplicates {
+		r.Recorder.Event(
+			ing, "Warning", "DuplicatePath",
+			fmt.Sprintf("Path %s is already routed by a previous rule, skipping", duplicate),
+		)
+	}
+
+	for _, rule := range rules {
+		if rule.Host == "" {
+			r.Recorder.Event(
+				ing, "Warning", "MissingHost",
+				"Ingress rules without host are not supported by Nginx Proxy Manager, skipping",
+			)
+			continue
+		}
+
+		name := controller.GeneratedName(ing.Name, strings.ReplaceAll(rule.Host, "*", "wildcard"))
+		if keep[proxyHostKey(name)] {
+			r.Recorder.Event(
+				ing, "Warning", "DuplicateProxyHost",
+				fmt.Sprintf("Host %s gets the name of the proxy host of a previous rule, ResourceName: %s, skipping", rule.Host, name),
+			)
+			continue
+		}
+
+		spec, err := r.buildProxyHostSpec(ing, rule, certificates[rule.Host], token)
+		if err != nil {
+			r.Recorder.Event(
+				ing, "Warning", "BuildProxyHost",
+				fmt.Sprintf("Failed to build proxy host for host %s, Namespace: %s, err: %s",
+					rule.Host, req.Namespace, err.Error()),
+			)
+			continue
+		}
+
+		if err := r.applyProxyHost(ctx, ing, name, spec); err != nil {
+			r.Recorder.Event(
+				ing, "Warning", "ApplyProxyHost",
+				fmt.Sprintf("Failed to apply proxy host, ResourceName: %s, Namespace: %s, err: %s",
+					name, req.Namespace, err.Error()),
+			)
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		keep[proxyHostKey(name)] = true
+	}
+
+	if err := r.deleteStaleObjects(ctx, ing, keep); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	if err := r.updateLoadBalancerStatus(ctx, req, ing, token); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// isManaged reports whether the ingress belongs to the configured ingress class
+func (r *IngressReconciler) isManaged(ing *networkingv1.Ingress) bool {
+	className := r.IngressClassName
+	if className == "" {
+		className = DEFAULT_INGRESS_CLASS
+	}
+
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName == className
+	}
+
+	return ing.Annotations[INGRESS_CLASS_ANNOTATION] == className
+}
+
+func (r *IngressReconciler) buildProxyHostSpec(ing *networkingv1.Ingress, rule networkingv1.IngressRule, certificateName string, token *nginxpmoperatoriov1.TokenName) (*nginxpmoperatoriov1.ProxyHostSpec, error) {
+	scheme := "http"
+	if protocol := strings.ToLower(ing.Annotations[INGRESS_BACKEND_PROTOCOL_ANNOTATION]); protocol == "https" {
+		scheme = protocol
+	}
+
+	var paths []networkingv1.HTTPIngressPath
+	if rule.HTTP != nil {
+		paths = rule.HTTP.Paths
+	}
+
+	// The root path of the rule is the main forward, otherwise the ingress default backend,
+	// otherwise the first path of the rule
+	var forwardBackend *networkingv1.IngressBackend
+	forwardIndex := -1
+
+	for i, path := range paths {
+		if path.Path == "" || path.Path == "/" {
+			forwardIndex = i
+			break
+		}
+	}
+
+	if forwardIndex == -1 && ing.Spec.DefaultBackend == nil && len(paths) > 0 {
+		forwardIndex = 0
+	}
+
+	if forwardIndex != -1 {
+		forwardBackend = &paths[forwardIndex].Backend
+	} else {
+		forwardBackend = ing.Spec.DefaultBackend
+	}
+
+	if forwardBackend == nil {
+		return nil, fmt.Errorf("no backend found for host %s", rule.Host)
+	}
+
+	forward, err := toProxyHostForward(scheme, forwardBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	// Additional paths become custom locations
+	var customLocations []nginxpmoperatoriov1.CustomLocation
+	for i, path := range paths {
+		if i == forwardIndex || path.Path == "" || path.Path == "/" {
+			continue
+		}
+
+		if !locationPathPattern.MatchString(path.Path) {
+			return nil, fmt.Errorf("path %s is not supported as a custom location", path.Path)
+		}
+
+		locationForward, err := toProxyHostForward(scheme, &path.Backend)
+		if err != nil {
+			return nil, err
+		}
+
+		customLocations = append(customLocations, nginxpmoperatoriov1.CustomLocation{
+			LocationPath: path.Path,
+			Forward:      *locationForward,
+		})
+	}
+
+	spec := &nginxpmoperatoriov1.ProxyHostSpec{
+		Token:            token,
+		DomainNames:      []nginxpmoperatoriov1.DomainName{nginxpmoperatoriov1.DomainName(rule.Host)},
+		BindExisting:     true,
+		BlockExploits:    true,
+		WebsocketSupport: true,
+		Forward:          *forward,
+		CustomLocations:  customLocations,
+	}
+
+	if certificateName != "" {
+		spec.Ssl = &nginxpmoperatoriov1.ProxyHostSsl{
+			CustomCertificate: &nginxpmoperatoriov1.SslCustomCertificate{Name: certificateName},
+			SslForced:         true,
+			Http2Support:      true,
+		}
+	}
+
+	return spec, nil
+}
+
+func (r *IngressReconciler) applyProxyHost(ctx context.Context, ing *networkingv1.Ingress, name string, spec *nginxpmoperatoriov1.ProxyHostSpec) error {
+	ph := &nginxpmoperatoriov1.ProxyHost{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ing.Namespace},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, ph, func() error {
+		if ph.Labels == nil {
+			ph.Labels = map[string]string{}
+		}
+		ph.Labels[INGRESS_LABEL] = ing.Name
+		ph.Spec = *spec
+
+		return controllerutil.SetControllerReference(ing, ph, r.Scheme)
+	})
+
+	return err
+}
+
+func (r *IngressReconciler) applyCustomCertificate(ctx context.Context, ing *networkingv1.Ingress, name, secretName string, token *nginxpmoperatoriov1.TokenName) error {
+	cc := &nginxpmoperatoriov1.CustomCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ing.Namespace},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cc, func() error {
+		if cc.Labels == nil {
+			cc.Labels = map[string]string{}
+		}
+		cc.Labels[INGRESS_LABEL] = ing.Name
+		cc.Spec.Token = token
+		cc.Spec.Certificate.Secret.Name = secretName
+
+		return controllerutil.SetControllerReference(ing, cc, r.Scheme)
+	})
+
+	return err
+}
+
+// deleteStaleObjects removes the objects generated for the ingress which are not part of keep
+func (r *IngressReconciler) deleteStaleObjects(ctx context.Context, ing *networkingv1.Ingress, keep map[string]bool) error {
+	log := log.FromContext(ctx)
+
+	listOps := []client.ListOption{
+		client.InNamespace(ing.Namespace),
+		client.MatchingLabels{INGRESS_LABEL: ing.Name},
+	}
+
+	proxyHosts := &nginxpmoperatoriov1.ProxyHostList{}
+	if err := r.List(ctx, proxyHosts, listOps...); err != nil {
+		return err
+	}
+
+	for _, ph := range proxyHosts.Items {
+		if keep[proxyHostKey(ph.Name)] || !metav1.IsControlledBy(&ph, ing) {
+			continue
+		}
+
+		log.Info("Deleting stale ProxyHost generated from ingress", "ProxyHost", ph.Name)
+		if err := r.Delete(ctx, &ph); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	customCertificates := &nginxpmoperatoriov1.CustomCertificateList{}
+	if err := r.List(ctx, customCertificates, listOps...); err != nil {
+		return err
+	}
+
+	for _, cc := range customCertificates.Items {
+		if keep[customCertificateKey(cc.Name)] || !metav1.IsControlledBy(&cc, ing) {
+			continue
+		}
+
+		log.Info("Deleting stale CustomCertificate generated from ingress", "CustomCertificate", cc.Name)
+		if err := r.Delete(ctx, &cc); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateLoadBalancerStatus publishes the Nginx Proxy Manager endpoint host in the ingress status
+func (r *IngressReconciler) updateLoadBalancerStatus(ctx context.Context, req ctrl.Request, ing *networkingv1.Ingress, tokenName *nginxpmoperatoriov1.TokenName) error {
+	log := log.FromContext(ctx)
+
+	var tokenEndpoint string
+	if controller.IsClusterTokenRef(tokenName) {
+		clusterToken, err := controller.FindClusterToken(ctx, r, req, tokenName)
+		if err != nil {
+			// Nothing to publish yet, the generated ProxyHosts report the missing token
+			return nil
+		}
+		tokenEndpoint = clusterToken.Spec.Endpoint
+	} else {
+		token, err := controller.FindToken(ctx, r, req, tokenName)
+		if err != nil {
+			// Nothing to publish yet, the generated ProxyHosts report the missing token
+			return nil
+		}
+		tokenEndpoint = token.Spec.Endpoint
+	}
+
+	endpoint, err := url.Parse(tokenEndpoint)
+	if err != nil || endpoint.Hostname() == "" {
+		log.Info("Unable to extract the host from the token endpoint", "endpoint", tokenEndpoint)
+		return nil
+	}
+
+	lbIngress := networkingv1.IngressLoadBalancerIngress{}
+	if net.ParseIP(endpoint.Hostname()) != nil {
+		lbIngress.IP = endpoint.Hostname()
+	} else {
+		lbIngress.Hostname = endpoint.Hostname()
+	}
+
+	loadBalancer := networkingv1.IngressLoadBalancerStatus{
+		Ingress: []networkingv1.IngressLoadBalancerIngress{lbIngress},
+	}
+
+	if equality.Semantic.DeepEqual(ing.Status.LoadBalancer, loadBalancer) {
+		return nil
+	}
+
+	ing.Status.LoadBalancer = loadBalancer
+	if err := r.Status().Update(ctx, ing); err != nil {
+		log.Error(err, "Failed to update ingress load balancer status")
+		return err
+	}
+
+	return nil
+}
+
+// ############################################# UTILS ##############################################
+
+func toProxyHostForward(scheme string, backend *networkingv1.IngressBackend) (*nginxpmoperatoriov1.ProxyHostForward, error) {
+	if backend.Service == nil {
+		return nil, fmt.Errorf("only service backends are supported")
+	}
+
+	service := &nginxpmoperatoriov1.ForwardService{Name: backend.Service.Name}
+
+	if backend.Service.Port.Number != 0 {
+		port := backend.Service.Port.Number
+		service.Port = &port
+	} else if backend.Service.Port.Name != "" {
+		portName := backend.Service.Port.Name
+		service.PortName = &portName
+	}
+
+	return &nginxpmoperatoriov1.ProxyHostForward{
+		Scheme:         scheme,
+		Service:        service,
+		NginxKeepalive: true,
+	}, nil
+}
+
+// mergeRulesByHost merges the rules sharing a host into the first of them, in the order of the rules.
+// The paths routed by a previous rule of the same host are dropped and returned as host+path.
+func mergeRulesByHost(rules []networkingv1.IngressRule) ([]networkingv1.IngressRule, []string) {
+	var merged []networkingv1.IngressRule
+	var duplicates []string
+
+	byHost := map[string]int{}
+	routed := map[string]bool{}
+
+	for _, rule := range rules {
+		var paths []networkingv1.HTTPIngressPath
+		if rule.HTTP != nil {
+			paths = rule.HTTP.Paths
+		}
+
+		i, found := byHost[rule.Host]
+		if !found || rule.Host == "" {
+			i = len(merged)
+			byHost[rule.Host] = i
+			merged = append(merged, networkingv1.IngressRule{Host: rule.Host})
+		}
+
+		for _, path := range paths {
+			key := rule.Host + path.Path
+			if path.Path == "" {
+				key += "/"
+			}
+
+			if routed[key] && rule.Host != "" {
+				duplicates = append(duplicates, key)
+				continue
+			}
+			routed[key] = true
+
+			if merged[i].HTTP == nil {
+				merged[i].HTTP = &networkingv1.HTTPIngressRuleValue{}
+			}
+			merged[i].HTTP.Paths = append(merged[i].HTTP.Paths, path)
+		}
+	}
+
+	return merged, duplicates
+}
+
+func proxyHostKey(name string) string {
+	return "ProxyHost/" + name
+}
+
+func customCertificateKey(name string) string {
+	return "CustomCertificate/" + name
+}
+
+// ############################################# CONTROLLER ##############################################
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkingv1.Ingress{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
+		Owns(&nginxpmoperatoriov1.ProxyHost{}).
+		Owns(&nginxpmoperatoriov1.CustomCertificate{}).
+		Named("ingress").
+		Complete(r)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestMergeRulesByHost(t *testing.T) {
+	rule := func(host string, paths ...string) networkingv1.IngressRule {
+		value := &networkingv1.HTTPIngressRuleValue{}
+		for _, path := range paths {
+			value.Paths = append(value.Paths, networkingv1.HTTPIngressPath{Path: path})
+		}
+
+		return networkingv1.IngressRule{Host: host, IngressRuleValue: networkingv1.IngressRuleValue{HTTP: value}}
+	}
+
+	rules, duplicates := mergeRulesByHost([]networkingv1.IngressRule{
+		rule("app.example.com", "/"),
+		rule("api.example.com", "/"),
+		rule("app.example.com", "/api", "/"),
+	})
+
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %v", rules)
+	}
+
+	if rules[0].Host != "app.example.com" || len(rules[0].HTTP.Paths) != 2 || rules[0].HTTP.Paths[1].Path != "/api" {
+		t.Errorf("Expected the paths of app.example.com to be merged, got %v", rules[0].HTTP.Paths)
+	}
+
+	if len(duplicates) != 1 || duplicates[0] != "app.example.com/" {
+		t.Errorf("Expected the second root path of app.example.com to be reported, got %v", duplicates)
+	}
+}
@@ -107,6 +107,18 @@ func (r *LetsEncryptCertificateReconciler) Reconcile(ctx context.Context, req ct
 		}
 	}
 
+	// Leave the certificate in NPM alone while the reconciliation is suspended,
+	// the finalizer is kept so a deletion waits until the resource is resumed
+	if controller.IsSuspended(lec, lec.Spec.Suspend) {
+		log.Info("Reconciliation is suspended, skipping")
+
+		controller.UpdateStatus(ctx, r.Client, lec, req.NamespacedName, func() {
+			controller.SetSuspendedCondition(&lec.Status.Conditions, lec.Generation, true)
+		})
+
+		return ctrl.Result{}, nil
+	}
+
 	// Let's just set the status as Unknown when no status is available
 	if len(lec.Status.Conditions) == 0 {
 		controller.UpdateStatus(ctx, r.Client, lec, req.NamespacedName, func() {
@@ -122,7 +134,7 @@ func (r *LetsEncryptCertificateReconciler) Reconcile(ctx context.Context, req ct
 
 	// Create a new Nginx Proxy Manager client
 	// If the client can't be created, we will remove the finalizer
-	nginxpmClient, err := controller.InitNginxPMClient(ctx, r, req, lec.Spec.Token)
+	nginxpmClient, tokenRef, err := controller.InitNginxPMClient(ctx, r, req, lec.Spec.Token)
 	if err != nil {
 		// Stop reconciliation if the resource is marked for deletion and the client can't be created
 		if isMarkedToBeDeleted {
@@ -161,10 +173,11 @@ func (r *LetsEncryptCertificateReconciler) Reconcile(ctx context.Context, req ct
 			log.Info("Performing Finalizer Operations for LetsEncryptCertificate")
 
 			// Delete the LetsEncryptCertificate record from remote  Nginx Proxy Manager instance
-			// If the LetsEncryptCertificate is bound, we will not delete the record
-			if lec.Status.Id != nil && !lec.Status.Bound {
+			// If the LetsEncryptCertificate is bound, we will not delete the record unless asked to
+			deletionPolicy := controller.DeletionPolicy(lec.Spec.DeletionPolicy, lec.Status.Bound, false, controller.DELETION_POLICY_ORPHAN)
+			if lec.Status.Id != nil && deletionPolicy == controller.DELETION_POLICY_DELETE {
 				log.Info("Deleting LetsEncryptCertificate record from remote NPM")
-				err := nginxpmClient.DeleteCertificate(int(*lec.Status.Id))
+				err := nginxpmClient.DeleteCertificateContext(ctx, int(*lec.Status.Id))
 
 				if err != nil {
 					log.Error(err, "Failed to delete LetsEncryptCertificate record from remote NPM")
@@ -180,6 +193,12 @@ func (r *LetsEncryptCertificateReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, nil
 	}
 
+	// Pin the token found by the fallback lookup, the resource keeps using it from now on
+	if err := controller.PinTokenReference(ctx, r.Client, lec, &lec.Spec.Token, tokenRef); err != nil {
+		log.Error(err, "Failed to pin the token reference of LetsEncryptCertificate")
+		return ctrl.Result{}, err
+	}
+
 	// Create Certificate or update the existing one
 	result, err := r.createCertificate(ctx, req, lec, nginxpmClient)
 	if err != nil {
@@ -197,8 +216,15 @@ func (r *LetsEncryptCertificateReconciler) Reconcile(ctx context.Context, req ct
 		return result, err
 	}
 
+	// Renew the certificate when it is close to its expiry or when asked to with the annotation
+	if err := r.renewCertificate(ctx, req, lec, nginxpmClient); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
 	// Set the status as True when the client can be created
 	controller.UpdateStatus(ctx, r.Client, lec, req.NamespacedName, func() {
+		lec.Status.TokenRef = tokenRef
+		controller.SetSuspendedCondition(&lec.Status.Conditions, lec.Generation, false)
 		meta.SetStatusCondition(&lec.Status.Conditions, metav1.Condition{
 			Status:             metav1.ConditionTrue,
 			Type:               controller.ConditionTypeReady,
@@ -208,7 +234,8 @@ func (r *LetsEncryptCertificateReconciler) Reconcile(ctx context.Context, req ct
 		})
 	})
 
-	return ctrl.Result{}, nil
+	// Come back before the next resync when the certificate is due for renewal sooner
+	return renewalResult(lec, time.Now()), nil
 }
 
 func (r *LetsEncryptCertificateReconciler) createCertificate(ctx context.Context, req ctrl.Request, lec *nginxpmoperatoriov1.LetsEncryptCertificate, nginxpmClient *nginxpm.Client) (ctrl.Result, error) {
@@ -225,17 +252,22 @@ func (r *LetsEncryptCertificateReconciler) createCertificate(ctx context.Context
 
 	// Let's check if the LetsEncryptCertificate is already created
 	if lec.Status.Id != nil {
-		certificate, err = nginxpmClient.FindLetEncryptCertificateByID(*lec.Status.Id)
+		certificate, err = nginxpmClient.FindLetEncryptCertificateByIDContext(ctx, *lec.Status.Id)
 		if err != nil {
 			log.Error(err, "Failed to find LetsEncryptCertificate by ID")
-			return ctrl.Result{RequeueAfter: time.Minute}, err
+			return controller.ResultForNginxPMError(nginxpmClient, err)
 		}
 
-		return ctrl.Result{}, nil
+		if certificate != nil {
+			return ctrl.Result{}, r.reportDrift(ctx, req, lec, domains, certificate)
+		}
+
+		// The certificate was deleted from Nginx Proxy Manager, let's request it again
+		log.Info("LetsEncryptCertificate not found in Nginx Proxy Manager, creating it again", "id", *lec.Status.Id)
 	}
 
 	// Let's create a new LetsEncryptCertificate from the LetsEncryptCertificate resource
-	if lec.Status.Id == nil {
+	if certificate == nil {
 		log.Info("Creating LetsEncryptCertificate")
 
 		hasDnsChallengeEnabled := lec.Spec.DnsChallenge != nil
@@ -259,7 +291,7 @@ func (r *LetsEncryptCertificateReconciler) createCertificate(ctx context.Context
 			fmt.Sprintf("Creating LetsEncryptCertificate for domains %s, ResourceName: %s, Namespace: %s", strings.Join(domains, ","), req.Name, req.Namespace),
 		)
 
-		certificate, err = nginxpmClient.CreateLetEncryptCertificate(
+		certificate, err = nginxpmClient.CreateLetEncryptCertificateContext(ctx,
 			nginxpm.CreateLetEncryptCertificateRequest{
 				DomainNames: domains,
 				Meta: nginxpm.CreateLetEncryptCertificateRequestMeta{
@@ -306,6 +338,29 @@ func (r *LetsEncryptCertificateReconciler) createCertificate(ctx context.Context
 	})
 }
 
+// reportDrift reports in the Drifted condition the domains of the certificate which do not match the spec,
+// a certificate can't be updated through the API so they are not corrected
+func (r *LetsEncryptCertificateReconciler) reportDrift(ctx context.Context, req ctrl.Request, lec *nginxpmoperatoriov1.LetsEncryptCertificate, domains []string, certificate *nginxpm.LetsEncryptCertificate) error {
+	var drift []string
+	if !controller.SameDomains(certificate.DomainNames, domains) {
+		drift = append(drift, fmt.Sprintf("domain_names: expected %v, found %v", domains, certificate.DomainNames))
+	}
+
+	if len(drift) > 0 && controller.DriftChanged(lec.Status.Conditions, controller.DRIFT_POLICY_REPORT, drift) {
+		r.Recorder.Event(
+			lec, "Warning", "DriftDetected",
+			fmt.Sprintf("Certificate domains do not match the spec, ResourceName: %s, Namespace: %s, drift: %s",
+				req.Name, req.Namespace, strings.Join(drift, "; ")),
+		)
+	}
+
+	return controller.UpdateStatus(ctx, r.Client, lec, req.NamespacedName, func() {
+		lec.Status.DomainNames = certificate.DomainNames
+		lec.Status.ExpiresOn = &certificate.ExpiresOn
+		controller.SetDriftedCondition(&lec.Status.Conditions, lec.Generation, controller.DRIFT_POLICY_REPORT, drift)
+	})
+}
+
 func (r *LetsEncryptCertificateReconciler) getDnsChallengeProviderCredentials(ctx context.Context, req ctrl.Request, lec *nginxpmoperatoriov1.LetsEncryptCertificate) (string, error) {
 	log := log.FromContext(ctx)
 
@@ -403,6 +458,11 @@ func (r *LetsEncryptCertificateReconciler) SetupWithManager(mgr ctrl.Manager) er
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(LEC_TOKEN_FIELD)),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&nginxpmoperatoriov1.ClusterToken{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(LEC_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Named("letsencryptcertificate").
 		Complete(r)
 }
@@ -0,0 +1,224 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package letsencryptcertificate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+const (
+	// RENEW_NOW_ANNOTATION asks for the certificate to be renewed right away, it is removed once the renewal was attempted
+	RENEW_NOW_ANNOTATION = "nginxpm-operator.io/renew-now"
+
+	// DEFAULT_RENEW_BEFORE is how long before its expiry a certificate is renewed when spec.renewBefore is not set
+	DEFAULT_RENEW_BEFORE = 14 * 24 * time.Hour
+
+	// RENEWAL_RETRY_PERIOD is how long to wait before renewing a certificate again after a failed renewal
+	RENEWAL_RETRY_PERIOD = time.Hour
+)
+
+// parseExpiresOn parses the expiry date of a certificate, NPM returns it in ISO 8601 or in its database format
+func parseExpiresOn(expiresOn string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, expiresOn); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown expiry date format %q", expiresOn)
+}
+
+// renewalTime returns when the certificate is due for renewal, false when its expiry is unknown
+func renewalTime(lec *nginxpmoperatoriov1.LetsEncryptCertificate) (time.Time, bool) {
+	if lec.Status.ExpiresOn == nil {
+		return time.Time{}, false
+	}
+
+	expiresOn, err := parseExpiresOn(*lec.Status.ExpiresOn)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	renewBefore := DEFAULT_RENEW_BEFORE
+	if lec.Spec.RenewBefore != nil {
+		renewBefore = lec.Spec.RenewBefore.Duration
+	}
+
+	return expiresOn.Add(-renewBefore), true
+}
+
+// nextRenewal returns when the certificate should be renewed, now when it is due.
+// A failed renewal is retried after RENEWAL_RETRY_PERIOD, even when the renewal is requested with the annotation.
+func nextRenewal(lec *nginxpmoperatoriov1.LetsEncryptCertificate, now time.Time) (time.Time, bool) {
+	_, requested := lec.GetAnnotations()[RENEW_NOW_ANNOTATION]
+
+	at, ok := renewalTime(lec)
+	if requested {
+		at, ok = now, true
+	}
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	if lec.Status.LastRenewalAttempt != nil && meta.IsStatusConditionTrue(lec.Status.Conditions, controller.ConditionTypeRenewalFailed) {
+		retryAt := lec.Status.LastRenewalAttempt.Add(RENEWAL_RETRY_PERIOD)
+		if retryAt.After(at) {
+			at = retryAt
+		}
+	}
+
+	if at.Before(now) {
+		at = now
+	}
+
+	return at, true
+}
+
+// renewalResult requeues the resource at its next resync, or earlier when the certificate is due for renewal before
+func renewalResult(lec *nginxpmoperatoriov1.LetsEncryptCertificate, now time.Time) ctrl.Result {
+	result := controller.ResyncResult()
+
+	at, ok := nextRenewal(lec, now)
+	if !ok {
+		return result
+	}
+
+	if until := at.Sub(now); result.RequeueAfter <= 0 || until < result.RequeueAfter {
+		result.RequeueAfter = max(until, time.Second)
+	}
+
+	return result
+}
+
+// renewCertificate renews the certificate in NPM when it is due or when the renewal is requested with the annotation,
+// the outcome is reported by the Renewing and RenewalFailed conditions
+func (r *LetsEncryptCertificateReconciler) renewCertificate(ctx context.Context, req ctrl.Request, lec *nginxpmoperatoriov1.LetsEncryptCertificate, nginxpmClient *nginxpm.Client) error {
+	log := log.FromContext(ctx)
+
+	if lec.Status.Id == nil {
+		return nil
+	}
+
+	now := time.Now()
+
+	if at, ok := nextRenewal(lec, now); !ok || at.After(now) {
+		return nil
+	}
+
+	// The annotation is removed first, a request is only honored once whatever the outcome of the renewal
+	if _, requested := lec.GetAnnotations()[RENEW_NOW_ANNOTATION]; requested {
+		patch := client.MergeFrom(lec.DeepCopy())
+		delete(lec.Annotations, RENEW_NOW_ANNOTATION)
+
+		if err := r.Patch(ctx, lec, patch); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Renewing LetsEncryptCertificate", "id", *lec.Status.Id)
+
+	r.Recorder.Event(
+		lec, "Normal", "RenewingLetsEncryptCertificate",
+		fmt.Sprintf("Renewing LetsEncryptCertificate, ResourceName: %s, Namespace: %s", req.Name, req.Namespace),
+	)
+
+	attempt := metav1.NewTime(now)
+	if err := controller.UpdateStatus(ctx, r.Client, lec, req.NamespacedName, func() {
+		lec.Status.LastRenewalAttempt = &attempt
+		meta.SetStatusCondition(&lec.Status.Conditions, metav1.Condition{
+			Status:             metav1.ConditionTrue,
+			Type:               controller.ConditionTypeRenewing,
+			Reason:             "Renewing",
+			Message:            "The certificate is being renewed by Nginx Proxy Manager",
+			ObservedGeneration: lec.Generation,
+		})
+	}); err != nil {
+		return err
+	}
+
+	certificate, err := nginxpmClient.RenewLetsEncryptCertificateContext(ctx, *lec.Status.Id)
+	if err != nil {
+		log.Error(err, "Failed to renew LetsEncryptCertificate")
+
+		r.Recorder.Event(
+			lec, "Warning", "RenewLetsEncryptCertificate",
+			fmt.Sprintf("Failed to renew LetsEncryptCertificate for domains %s, ResourceName: %s, Namespace: %s, err: %s",
+				strings.Join(lec.Status.DomainNames, ","), req.Name, req.Namespace, err.Error()),
+		)
+
+		return controller.UpdateStatus(ctx, r.Client, lec, req.NamespacedName, func() {
+			setRenewalConditions(&lec.Status.Conditions, lec.Generation, err)
+		})
+	}
+
+	r.Recorder.Event(
+		lec, "Normal", "RenewedLetsEncryptCertificate",
+		fmt.Sprintf("Renewed LetsEncryptCertificate, expires on %s, ResourceName: %s, Namespace: %s", certificate.ExpiresOn, req.Name, req.Namespace),
+	)
+
+	renewed := metav1.NewTime(time.Now())
+	return controller.UpdateStatus(ctx, r.Client, lec, req.NamespacedName, func() {
+		lec.Status.ExpiresOn = &certificate.ExpiresOn
+		lec.Status.LastRenewed = &renewed
+		setRenewalConditions(&lec.Status.Conditions, lec.Generation, nil)
+	})
+}
+
+// setRenewalConditions records the outcome of a renewal in the Renewing and RenewalFailed conditions
+func setRenewalConditions(conditions *[]metav1.Condition, generation int64, err error) {
+	renewing := metav1.Condition{
+		Status:             metav1.ConditionFalse,
+		Type:               controller.ConditionTypeRenewing,
+		Reason:             "Renewed",
+		Message:            "The certificate was renewed",
+		ObservedGeneration: generation,
+	}
+
+	failed := metav1.Condition{
+		Status:             metav1.ConditionFalse,
+		Type:               controller.ConditionTypeRenewalFailed,
+		Reason:             "Renewed",
+		Message:            "The last renewal succeeded",
+		ObservedGeneration: generation,
+	}
+
+	if err != nil {
+		renewing.Reason = "RenewalFailed"
+		renewing.Message = "The last renewal failed"
+
+		failed.Status = metav1.ConditionTrue
+		failed.Reason = "RenewalFailed"
+		failed.Message = err.Error()
+	}
+
+	meta.SetStatusCondition(conditions, renewing)
+	meta.SetStatusCondition(conditions, failed)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package letsencryptcertificate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+)
+
+var errTest = errors.New("too many failed authorizations recently")
+
+func TestNextRenewal(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	certificate := func(expiresOn string) *nginxpmoperatoriov1.LetsEncryptCertificate {
+		return &nginxpmoperatoriov1.LetsEncryptCertificate{
+			Status: nginxpmoperatoriov1.LetsEncryptCertificateStatus{ExpiresOn: &expiresOn},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		lec      *nginxpmoperatoriov1.LetsEncryptCertificate
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name:     "Not due yet",
+			lec:      certificate("2024-08-01T12:00:00Z"),
+			expected: time.Date(2024, 7, 18, 12, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Due, in the database format",
+			lec:      certificate("2024-06-10 12:00:00"),
+			expected: now,
+			ok:       true,
+		},
+		{
+			name: "Custom window",
+			lec: func() *nginxpmoperatoriov1.LetsEncryptCertificate {
+				lec := certificate("2024-08-01T12:00:00Z")
+				lec.Spec.RenewBefore = &metav1.Duration{Duration: 24 * time.Hour}
+				return lec
+			}(),
+			expected: time.Date(2024, 7, 31, 12, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name: "Requested with the annotation",
+			lec: func() *nginxpmoperatoriov1.LetsEncryptCertificate {
+				lec := certificate("2024-08-01T12:00:00Z")
+				lec.Annotations = map[string]string{RENEW_NOW_ANNOTATION: "true"}
+				return lec
+			}(),
+			expected: now,
+			ok:       true,
+		},
+		{
+			name: "Retried after a failure",
+			lec: func() *nginxpmoperatoriov1.LetsEncryptCertificate {
+				lec := certificate("2024-06-10T12:00:00Z")
+				attempt := metav1.NewTime(now.Add(-10 * time.Minute))
+				lec.Status.LastRenewalAttempt = &attempt
+				setRenewalConditions(&lec.Status.Conditions, lec.Generation, errTest)
+				return lec
+			}(),
+			expected: now.Add(50 * time.Minute),
+			ok:       true,
+		},
+		{
+			name: "Unknown expiry",
+			lec:  &nginxpmoperatoriov1.LetsEncryptCertificate{},
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, ok := nextRenewal(tt.lec, now)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+
+			if !at.Equal(tt.expected) {
+				t.Errorf("Expected renewal at %s, got %s", tt.expected, at)
+			}
+		})
+	}
+}
+
+func TestRenewalResult(t *testing.T) {
+	now := time.Now()
+	expiresOn := now.Add(DEFAULT_RENEW_BEFORE + time.Minute).Format(time.RFC3339)
+
+	lec := &nginxpmoperatoriov1.LetsEncryptCertificate{
+		Status: nginxpmoperatoriov1.LetsEncryptCertificateStatus{ExpiresOn: &expiresOn},
+	}
+
+	result := renewalResult(lec, now)
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Minute {
+		t.Errorf("Expected a requeue within a minute, got %s", result.RequeueAfter)
+	}
+
+	expiresOn = now.AddDate(0, 2, 0).Format(time.RFC3339)
+	if result := renewalResult(lec, now); result.RequeueAfter != controller.ResyncPeriod {
+		t.Errorf("Expected a requeue at the next resync, got %s", result.RequeueAfter)
+	}
+}
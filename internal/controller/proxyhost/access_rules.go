@@ -0,0 +1,145 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxyhost
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// accessRulesListName names the NPM access list created for the access rules of the proxy host
+func accessRulesListName(ph *nginxpmoperatoriov1.ProxyHost) string {
+	return fmt.Sprintf("proxyhost-%s-%s", ph.Namespace, ph.Name)
+}
+
+// makeAccessRulesList creates or updates the NPM access list of the access rules of the proxy host,
+// it returns the access list and the hash of the rules it was last written with
+func (r *ProxyHostReconciler) makeAccessRulesList(ctx context.Context, ph *nginxpmoperatoriov1.ProxyHost, nginxpmClient *nginxpm.Client) (*nginxpm.AccessList, string, error) {
+	log := log.FromContext(ctx)
+
+	rules := ph.Spec.AccessRules
+
+	input := nginxpm.AccessListRequestInput{
+		Name:       accessRulesListName(ph),
+		SatisfyAny: rules.SatisfyAny,
+		PassAuth:   rules.PassAuth,
+		Items:      []nginxpm.AccessListItem{},
+		Clients:    make([]nginxpm.AccessListClient, len(rules.Clients)),
+	}
+
+	for i, client := range rules.Clients {
+		input.Clients[i] = nginxpm.AccessListClient{Address: client.Address, Directive: client.Directive}
+	}
+
+	if rules.AuthorizationsSecretRef != nil {
+		value, err := controller.GetSecretKeyValue(ctx, r, ph.Namespace, rules.AuthorizationsSecretRef)
+		if err != nil {
+			return nil, "", fmt.Errorf("access rules authorizations: %w", err)
+		}
+
+		items, err := controller.ParseAuthorizations(value)
+		if err != nil {
+			return nil, "", fmt.Errorf("access rules authorizations of secret %s: %w", rules.AuthorizationsSecretRef.Name, err)
+		}
+		input.Items = append(input.Items, items...)
+	}
+
+	// The rules hold no password in the spec, the version of the Secret stands for the passwords it holds
+	var secretVersions []string
+	if rules.AuthorizationsSecretRef != nil {
+		secretVersions = controller.SecretVersions(ctx, r, ph.Namespace, []string{rules.AuthorizationsSecretRef.Name})
+	}
+
+	configHash := controller.HashAccessListConfig(input, 0, secretVersions)
+
+	var accessList *nginxpm.AccessList
+	var err error
+
+	if ph.Status.AccessRulesListId != nil {
+		accessList, err = nginxpmClient.FindAccessListByIDContext(ctx, *ph.Status.AccessRulesListId)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	// The list may have been created by an earlier reconcile which failed before saving its id
+	if accessList == nil {
+		accessList, err = nginxpmClient.FindAccessListByNameContext(ctx, input.Name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if accessList != nil {
+			// Its content is unknown, it is written again
+			ph.Status.AccessRulesConfigHash = ""
+		}
+	}
+
+	if accessList == nil {
+		accessList, err = nginxpmClient.CreateAccessListContext(ctx, input)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Info("Access rules list created successfully", "accessListId", accessList.ID)
+
+		// The id is saved right away, the status of the proxy host is only written at the end of the reconcile
+		id := accessList.ID
+		latestPh := &nginxpmoperatoriov1.ProxyHost{}
+		if err := controller.UpdateStatus(ctx, r.Client, latestPh, types.NamespacedName{Name: ph.Name, Namespace: ph.Namespace}, func() {
+			latestPh.Status.AccessRulesListId = &id
+			latestPh.Status.AccessRulesConfigHash = configHash
+		}); err != nil {
+			return nil, "", err
+		}
+	} else if ph.Status.AccessRulesConfigHash != configHash {
+		accessList, err = nginxpmClient.UpdateAccessListContext(ctx, accessList.ID, input)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Info("Access rules list updated successfully", "accessListId", accessList.ID)
+	}
+
+	return accessList, configHash, nil
+}
+
+// deleteAccessRulesList deletes the NPM access list created for the access rules of the proxy host, if any,
+// it is looked up by its name when its id was not saved
+func deleteAccessRulesList(ctx context.Context, ph *nginxpmoperatoriov1.ProxyHost, nginxpmClient *nginxpm.Client) error {
+	var accessList *nginxpm.AccessList
+	var err error
+
+	if ph.Status.AccessRulesListId != nil {
+		accessList, err = nginxpmClient.FindAccessListByIDContext(ctx, *ph.Status.AccessRulesListId)
+	} else if ph.Spec.AccessRules != nil {
+		accessList, err = nginxpmClient.FindAccessListByNameContext(ctx, accessRulesListName(ph))
+	}
+
+	if err != nil || accessList == nil {
+		return err
+	}
+
+	return nginxpmClient.DeleteAccessListContext(ctx, accessList.ID)
+}
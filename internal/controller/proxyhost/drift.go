@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxyhost
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// DiffProxyHost compares the live proxy host with the input built from the spec,
+// it returns one "field: expected X, found Y" entry per field which differs.
+func DiffProxyHost(live *nginxpm.ProxyHost, input nginxpm.ProxyHostRequestInput) []string {
+	var diff []string
+
+	compare := func(field string, expected, found interface{}) {
+		if expected != found {
+			diff = append(diff, fmt.Sprintf("%s: expected %v, found %v", field, expected, found))
+		}
+	}
+
+	if !controller.SameDomains(live.DomainNames, input.DomainNames) {
+		diff = append(diff, fmt.Sprintf("domain_names: expected %v, found %v", input.DomainNames, live.DomainNames))
+	}
+
+	compare("forward_scheme", input.ForwardScheme, live.ForwardScheme)
+	compare("forward_host", input.ForwardHost, live.ForwardHost)
+	compare("forward_port", input.ForwardPort, live.ForwardPort)
+	compare("block_exploits", input.BlockExploits, live.BlockExploits)
+	compare("allow_websocket_upgrade", input.AllowWebsocketUpgrade, live.AllowWebsocketUpgrade)
+	compare("caching_enabled", input.CachingEnabled, live.CachingEnabled)
+	compare("access_list_id", input.AccessListID, live.AccessListID)
+	compare("ssl_forced", input.SSLForced, live.SSLForced)
+	compare("http2_support", input.HTTP2Support, live.HTTP2Support)
+	compare("hsts_enabled", input.HSTSEnabled, live.HSTSEnabled)
+	compare("hsts_subdomains", input.HSTSSubdomains, live.HSTSSubdomains)
+
+	certificateID := 0
+	if input.CertificateID != nil {
+		certificateID = *input.CertificateID
+	}
+	compare("certificate_id", certificateID, live.CertificateID)
+
+	if strings.TrimSpace(input.AdvancedConfig) != strings.TrimSpace(live.AdvancedConfig) {
+		diff = append(diff, "advanced_config: changed")
+	}
+
+	if custom, ok := input.CustomFields[nginxpm.CUSTOM_FIELD_UNSCOPED_CONFIG]; ok && custom.Allowed && live.UnscopedConfig != nil {
+		if strings.TrimSpace(custom.Value) != strings.TrimSpace(*live.UnscopedConfig) {
+			diff = append(diff, fmt.Sprintf("%s: changed", nginxpm.CUSTOM_FIELD_UNSCOPED_CONFIG))
+		}
+	}
+
+	if len(input.Locations) != len(live.Locations) {
+		diff = append(diff, fmt.Sprintf("locations: expected %d, found %d", len(input.Locations), len(live.Locations)))
+	} else {
+		for i, location := range input.Locations {
+			found := live.Locations[i]
+			field := fmt.Sprintf("locations[%d]", i)
+
+			compare(field+".path", location.Path, found.Path)
+			compare(field+".forward_scheme", location.ForwardScheme, found.ForwardScheme)
+			compare(field+".forward_host", location.ForwardHost, found.ForwardHost)
+			compare(field+".forward_port", location.ForwardPort, found.ForwardPort)
+
+			if strings.TrimSpace(location.AdvancedConfig) != strings.TrimSpace(found.AdvancedConfig) {
+				diff = append(diff, field+".advanced_config: changed")
+			}
+		}
+	}
+
+	return diff
+}
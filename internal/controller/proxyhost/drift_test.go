@@ -0,0 +1,65 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxyhost
+
+import (
+	"testing"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+func TestDiffProxyHost(t *testing.T) {
+	certificateID := 4
+
+	input := nginxpm.ProxyHostRequestInput{
+		DomainNames:   []string{"App.example.com", "example.com"},
+		ForwardScheme: "http",
+		ForwardHost:   "10.0.0.1",
+		ForwardPort:   8080,
+		BlockExploits: true,
+		CertificateID: &certificateID,
+		Locations:     []nginxpm.ProxyHostLocation{{Path: "/api", ForwardScheme: "http", ForwardHost: "10.0.0.2", ForwardPort: 9090}},
+	}
+
+	live := &nginxpm.ProxyHost{
+		DomainNames:   []string{"example.com", "app.example.com"},
+		ForwardScheme: "http",
+		ForwardHost:   "10.0.0.1",
+		ForwardPort:   8080,
+		BlockExploits: true,
+		CertificateID: certificateID,
+		Enabled:       true,
+		Locations:     []nginxpm.ProxyHostLocation{{Path: "/api", ForwardScheme: "http", ForwardHost: "10.0.0.2", ForwardPort: 9090}},
+	}
+
+	if diff := DiffProxyHost(live, input); len(diff) != 0 {
+		t.Errorf("Expected no drift, got %v", diff)
+	}
+
+	live.ForwardPort = 9000
+	live.CertificateID = 0
+	live.Locations[0].ForwardPort = 9091
+
+	diff := DiffProxyHost(live, input)
+	if len(diff) != 3 {
+		t.Fatalf("Expected 3 drifted fields, got %v", diff)
+	}
+
+	if diff[0] != "forward_port: expected 8080, found 9000" {
+		t.Errorf("Unexpected drift entry %q", diff[0])
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxyhost
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+func TestEnabledRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  *bool
+		expected bool
+	}{
+		{name: "Not set", enabled: nil, expected: true},
+		{name: "Enabled", enabled: ptr.To(true), expected: true},
+		{name: "Disabled", enabled: ptr.To(false), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := &nginxpmoperatoriov1.ProxyHost{Spec: nginxpmoperatoriov1.ProxyHostSpec{Enabled: tt.enabled}}
+
+			// The whole object is sent on updates, a disabled proxy host must keep its enabled field
+			data, err := json.Marshal(ph)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			decoded := &nginxpmoperatoriov1.ProxyHost{}
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if (decoded.Spec.Enabled == nil) != (tt.enabled == nil) {
+				t.Errorf("Expected enabled to be kept as %v, got %v", tt.enabled, decoded.Spec.Enabled)
+			}
+
+			if enabled := isEnabled(decoded); enabled != tt.expected {
+				t.Errorf("Expected the proxy host to be enabled: %v, got %v", tt.expected, enabled)
+			}
+		})
+	}
+}
@@ -26,7 +26,9 @@ type MakeForwardOption struct {
 	Label                   string
 }
 
-func (r *ProxyHostReconciler) makeForward(option MakeForwardOption) (*ProxyHostForward, error) {
+// MakeForward resolves a ProxyHostForward spec (hosts or Service) into the upstream
+// host and port used by Nginx Proxy Manager.
+func (r *ProxyHostReconciler) MakeForward(option MakeForwardOption) (*ProxyHostForward, error) {
 	log := log.FromContext(option.Ctx)
 
 	forward := option.Forward
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -62,7 +64,7 @@ const (
 
 	PH_ACCESS_LIST_FIELD = ".spec.accessList.name"
 
-	DEFAULT_EMAIL = "support@nginxpm-operator.io"
+	PH_ACCESS_RULES_SECRET_FIELD = ".spec.accessRules.authorizationsSecretRef.name"
 )
 
 // ProxyHostReconciler reconciles a ProxyHost object
@@ -98,6 +100,7 @@ type ProxyHostForward struct {
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=nodes/status,verbs=get
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -136,6 +139,18 @@ func (r *ProxyHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// Leave the proxy host in NPM alone while the reconciliation is suspended,
+	// the finalizer is kept so a deletion waits until the resource is resumed
+	if controller.IsSuspended(ph, ph.Spec.Suspend) {
+		log.Info("Reconciliation is suspended, skipping")
+
+		controller.UpdateStatus(ctx, r.Client, ph, req.NamespacedName, func() {
+			controller.SetSuspendedCondition(&ph.Status.Conditions, ph.Generation, true)
+		})
+
+		return ctrl.Result{}, nil
+	}
+
 	// Let's just set the status as Unknown when no status is available
 	if len(ph.Status.Conditions) == 0 {
 		controller.UpdateStatus(ctx, r.Client, ph, req.NamespacedName, func() {
@@ -150,7 +165,7 @@ func (r *ProxyHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// Create a new Nginx Proxy Manager client
-	nginxpmClient, err := controller.InitNginxPMClient(ctx, r, req, ph.Spec.Token)
+	nginxpmClient, tokenRef, err := controller.InitNginxPMClient(ctx, r, req, ph.Spec.Token)
 	if err != nil {
 		// Stop reconciliation if the resource is marked for deletion and the client can't be created
 		if isMarkedToBeDeleted {
@@ -204,18 +219,19 @@ func (r *ProxyHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if controllerutil.ContainsFinalizer(ph, proxyHostFinalizer) {
 			log.Info("Performing Finalizer Operations for ProxyHost")
 
+			deletionPolicy := controller.DeletionPolicy(ph.Spec.DeletionPolicy, ph.Status.Bound, ShouldRestoreInitialConfig(ph), controller.DELETION_POLICY_DISABLE)
+
 			// Delete the ProxyHost record from remote  Nginx Proxy Manager instance
 			if ph.Status.Id != nil {
-				// If the ProxyHost is bound and has initial configuration, restore it
-				// Otherwise, delete or disable as before
-				if ShouldRestoreInitialConfig(ph) {
+				switch deletionPolicy {
+				case controller.DELETION_POLICY_RESTORE:
 					log.Info("Restoring initial configuration for bound ProxyHost", "proxyHostId", *ph.Status.Id)
 
 					// Build restoration input from stored initial configuration
 					restorationInput := BuildRestorationInput(ph.Status.InitialConfiguration)
 					if restorationInput != nil {
 						// Update the proxy host with the original configuration
-						_, err := nginxpmClient.UpdateProxyHost(int(*ph.Status.Id), *restorationInput)
+						_, err := nginxpmClient.UpdateProxyHostContext(ctx, int(*ph.Status.Id), *restorationInput)
 						if err != nil {
 							log.Error(err, "Failed to restore initial configuration for ProxyHost")
 						} else {
@@ -224,28 +240,36 @@ func (r *ProxyHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 						// Re-enable the proxy host if it was disabled and originally enabled
 						if ph.Status.InitialConfiguration.Enabled {
-							err := nginxpmClient.EnableProxyHost(int(*ph.Status.Id))
+							err := nginxpmClient.EnableProxyHostContext(ctx, int(*ph.Status.Id))
 							if err != nil {
 								log.Error(err, "Failed to re-enable ProxyHost after restoration")
 							}
 						}
 					}
-				} else if ph.Status.Bound {
-					// Bound but no initial config stored (legacy behavior)
-					log.Info("Disabling ProxyHost record from remote NPM (no initial config to restore)")
+				case controller.DELETION_POLICY_DISABLE:
+					log.Info("Disabling ProxyHost record from remote NPM")
 
-					err := nginxpmClient.DisableProxyHost(int(*ph.Status.Id))
+					err := nginxpmClient.DisableProxyHostContext(ctx, int(*ph.Status.Id))
 					if err != nil {
 						log.Error(err, "Failed to disable ProxyHost record from remote NPM")
 					}
-				} else {
-					// Not bound, so we created it - delete it
+				case controller.DELETION_POLICY_DELETE:
 					log.Info("Deleting ProxyHost record from remote NPM")
-					err := nginxpmClient.DeleteProxyHost(int(*ph.Status.Id))
+					err := nginxpmClient.DeleteProxyHostContext(ctx, int(*ph.Status.Id))
 
 					if err != nil {
 						log.Error(err, "Failed to delete ProxyHost record from remote NPM")
 					}
+				default:
+					log.Info("Leaving ProxyHost record in remote NPM", "deletionPolicy", deletionPolicy)
+				}
+			}
+
+			// The access list of the access rules is only used by this proxy host, it can exist
+			// even when the proxy host itself was never created
+			if deletionPolicy != controller.DELETION_POLICY_ORPHAN {
+				if err := deleteAccessRulesList(ctx, ph, nginxpmClient); err != nil {
+					log.Error(err, "Failed to delete the access rules list from remote NPM")
 				}
 			}
 
@@ -272,6 +296,12 @@ func (r *ProxyHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	// Pin the token found by the fallback lookup, the resource keeps using it from now on
+	if err := controller.PinTokenReference(ctx, r.Client, ph, &ph.Spec.Token, tokenRef); err != nil {
+		log.Error(err, "Failed to pin the token reference of ProxyHost")
+		return ctrl.Result{}, err
+	}
+
 	// Domains should be unique
 	_, err = r.domainsShouldBeUnique(ctx, ph)
 	if err != nil {
@@ -302,11 +332,13 @@ func (r *ProxyHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			})
 		})
 
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return controller.ResultForNginxPMError(nginxpmClient, err)
 	}
 
 	// Set the status as True when the client can be created
 	controller.UpdateStatus(ctx, r.Client, ph, req.NamespacedName, func() {
+		ph.Status.TokenRef = tokenRef
+		controller.SetSuspendedCondition(&ph.Status.Conditions, ph.Generation, false)
 		meta.SetStatusCondition(&ph.Status.Conditions, metav1.Condition{
 			Status:             metav1.ConditionTrue,
 			Type:               controller.ConditionTypeReady,
@@ -316,7 +348,7 @@ func (r *ProxyHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		})
 	})
 
-	return ctrl.Result{}, nil
+	return controller.ResyncResult(), nil
 }
 
 func (r *ProxyHostReconciler) domainsShouldBeUnique(ctx context.Context, ph *nginxpmoperatoriov1.ProxyHost) (bool, error) {
@@ -375,7 +407,7 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 
 	// Let's check if the proxy host is already created
 	if ph.Status.Id != nil {
-		proxyHost, err = nginxpmClient.FindProxyHostByID(*ph.Status.Id)
+		proxyHost, err = nginxpmClient.FindProxyHostByIDContext(ctx, *ph.Status.Id)
 		if err != nil {
 			r.Recorder.Event(
 				ph, "Warning", "FindProxyHostByID",
@@ -392,7 +424,7 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 
 	} else if ph.Spec.BindExisting {
 		// If finding by ID doesn't match a record, we search for the proxy host by domain.
-		proxyHost, _ = nginxpmClient.FindProxyHostByDomain(domains)
+		proxyHost, _ = nginxpmClient.FindProxyHostByDomainContext(ctx, domains)
 
 		if proxyHost != nil {
 			bound = true
@@ -405,16 +437,10 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 		}
 	}
 
-	// Enable ProxyHost if disabled
-	if proxyHost != nil && !proxyHost.Enabled {
-		log.Info("Enabling ProxyHost")
-		nginxpmClient.EnableProxyHost(proxyHost.ID)
-	}
-
 	unscopedConfigSupported := controller.JsonFieldExists(proxyHost, nginxpm.CUSTOM_FIELD_UNSCOPED_CONFIG)
 
 	// ProxyHost forward operation
-	proxyHostForward, err := r.makeForward(MakeForwardOption{
+	proxyHostForward, err := r.MakeForward(MakeForwardOption{
 		Ctx:                     ctx,
 		Req:                     req,
 		ProxyHost:               ph,
@@ -451,9 +477,25 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 		}
 	}
 
-	// AccessList operation
+	// AccessList operation, the access rules of the spec get an access list of their own
 	var accessListID int
-	if ph.Spec.AccessList != nil {
+	var accessRulesListId *int
+	var accessRulesConfigHash string
+	if ph.Spec.AccessRules != nil {
+		accessList, configHash, err := r.makeAccessRulesList(ctx, ph, nginxpmClient)
+		if err != nil {
+			r.Recorder.Event(
+				ph, "Warning", "MakeAccessRulesList",
+				fmt.Sprintf("Failed to create or update the access rules list, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+			return err
+		}
+
+		accessListID = accessList.ID
+		accessRulesListId = &accessList.ID
+		accessRulesConfigHash = configHash
+	} else if ph.Spec.AccessList != nil {
 		accessList, err := r.getAccessListByReference(ctx, req, ph.Spec.AccessList, nginxpmClient)
 		if err != nil {
 			r.Recorder.Event(
@@ -503,6 +545,9 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 		// We need to call again controller.JsonFieldExists here since the proxyHost could be nil
 		unscopedConfigSupported := controller.JsonFieldExists(proxyHost, nginxpm.CUSTOM_FIELD_UNSCOPED_CONFIG)
 		nginxUpstreamConfig := mergeNginxUpstreamConfigs(proxyHostForward.NginxUpstreamConfigs)
+		if ph.Spec.UnscopedConfig != "" {
+			nginxUpstreamConfig = strings.TrimSpace(ph.Spec.UnscopedConfig + "\n" + nginxUpstreamConfig)
+		}
 
 		// We are doing this for compatibility reasons
 		input.CustomFields[nginxpm.CUSTOM_FIELD_UNSCOPED_CONFIG] = nginxpm.RequestCustomField{
@@ -535,9 +580,32 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 		input.HSTSSubdomains = ph.Spec.Ssl.HstsSubdomains
 	}
 
-	// Update proxy host
-	if proxyHost != nil {
-		proxyHost, err = nginxpmClient.UpdateProxyHost(proxyHost.ID, input)
+	// Look for changes made outside of the operator, the proxy host is updated
+	// when the configuration built from the spec is not the one applied last
+	configHash := controller.HashConfig(input)
+	driftPolicy := controller.DriftPolicy(ph.Spec.DriftPolicy)
+
+	drift, upToDate := controller.CheckDrift(driftPolicy, proxyHost != nil, ph.Status.AppliedConfigHash, configHash, func() []string {
+		return DiffProxyHost(proxyHost, input)
+	})
+
+	if len(drift) > 0 {
+		log.Info("ProxyHost drifted from its spec", "drift", drift, "driftPolicy", driftPolicy)
+
+		if controller.DriftChanged(ph.Status.Conditions, driftPolicy, drift) {
+			r.Recorder.Event(
+				ph, "Warning", "DriftDetected",
+				fmt.Sprintf("Proxy host changed outside of the operator, ResourceName: %s, Namespace: %s, drift: %s",
+					req.Name, req.Namespace, strings.Join(drift, "; ")),
+			)
+		}
+	}
+
+	if upToDate {
+		log.Info("ProxyHost is up to date")
+	} else if proxyHost != nil {
+		// Update proxy host
+		proxyHost, err = nginxpmClient.UpdateProxyHostContext(ctx, proxyHost.ID, input)
 		if err != nil {
 			r.Recorder.Event(
 				ph, "Warning", "UpdateProxyHost",
@@ -552,7 +620,7 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 		log.Info("ProxyHost updated successfully")
 	} else {
 		// Create proxy host
-		proxyHost, err = nginxpmClient.CreateProxyHost(input)
+		proxyHost, err = nginxpmClient.CreateProxyHostContext(ctx, input)
 		if err != nil {
 			r.Recorder.Event(
 				ph, "Warning", "CreateProxyHost",
@@ -567,17 +635,53 @@ func (r *ProxyHostReconciler) createOrUpdateProxyHost(ctx context.Context, req c
 		// In case not all custom field supported, we send update request
 		if !allCustomFieldsSupported {
 			withCustomFields(proxyHost, &input) // call withCustomFields again to ensure all custom fields are supported
-			nginxpmClient.UpdateProxyHost(proxyHost.ID, input)
+			nginxpmClient.UpdateProxyHostContext(ctx, proxyHost.ID, input)
 		}
 
 		log.Info("ProxyHost created successfully")
 	}
 
+	// Delete the access list of the access rules removed from the spec, the proxy host no longer uses it
+	if ph.Spec.AccessRules == nil && ph.Status.AccessRulesListId != nil {
+		if err := deleteAccessRulesList(ctx, ph, nginxpmClient); err != nil {
+			log.Error(err, "Failed to delete the access rules list from remote NPM")
+			accessRulesListId = ph.Status.AccessRulesListId
+		} else {
+			log.Info("Access rules list deleted successfully")
+		}
+	}
+
+	// Enable or disable the proxy host as requested by the spec
+	if enabled := isEnabled(ph); proxyHost.Enabled != enabled {
+		if enabled {
+			log.Info("Enabling ProxyHost")
+			err = nginxpmClient.EnableProxyHostContext(ctx, proxyHost.ID)
+		} else {
+			log.Info("Disabling ProxyHost")
+			err = nginxpmClient.DisableProxyHostContext(ctx, proxyHost.ID)
+		}
+
+		if err != nil {
+			r.Recorder.Event(
+				ph, "Warning", "SetProxyHostEnabled",
+				fmt.Sprintf("Failed to enable or disable proxy host, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to enable or disable proxy host")
+			return err
+		}
+	}
+
 	return controller.UpdateStatus(ctx, r.Client, ph, req.NamespacedName, func() {
 		ph.Status.Id = &proxyHost.ID
 		ph.Status.Online = proxyHost.Meta.NginxOnline
 		ph.Status.CertificateId = certificateID
 		ph.Status.Bound = bound
+		ph.Status.AppliedConfigHash = configHash
+		ph.Status.AccessRulesListId = accessRulesListId
+		ph.Status.AccessRulesConfigHash = accessRulesConfigHash
+		controller.SetDriftedCondition(&ph.Status.Conditions, ph.Generation, driftPolicy, drift)
 		// Set or preserve the initial configuration
 		if capturedInitialConfig != nil {
 			ph.Status.InitialConfiguration = capturedInitialConfig
@@ -593,7 +697,7 @@ func (r *ProxyHostReconciler) constructCustomLocation(ctx context.Context, req c
 	customLocations := make([]nginxpm.ProxyHostLocation, len(ph.Spec.CustomLocations))
 
 	for i, location := range ph.Spec.CustomLocations {
-		forward, err := r.makeForward(MakeForwardOption{
+		forward, err := r.MakeForward(MakeForwardOption{
 			Ctx:                     ctx,
 			Req:                     req,
 			ProxyHost:               ph,
@@ -665,7 +769,7 @@ func (r *ProxyHostReconciler) getAccessListByReference(ctx context.Context, req
 		remoteId = acl.Status.Id
 	}
 
-	accessList, err := nginxpmClient.FindAccessListByID(*remoteId)
+	accessList, err := nginxpmClient.FindAccessListByIDContext(ctx, *remoteId)
 	if err != nil {
 		log.Error(err, "Failed to find access list by ID")
 		return nil, err
@@ -702,68 +806,10 @@ func (r *ProxyHostReconciler) makeCertificate(ctx context.Context, req ctrl.Requ
 	// then we find or create a new certificate from Let's Encrypt
 	if ph.Spec.Ssl.AutoCertificateRequest && certificate == nil {
 		log.Info("Since no LetsEncryptCertificate, CustomCertificate, or CertificateId is provided, AutoCertificateRequest is enabled, finding or creating certificate")
-		certificate, err = r.findOrCreateCertificate(ctx, ph, nginxpmClient)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return certificate, nil
-}
-
-// Find certificate by domain name
-// If certificate is not found, create a new one from Let's Encrypt
-func (r *ProxyHostReconciler) findOrCreateCertificate(ctx context.Context, ph *nginxpmoperatoriov1.ProxyHost, nginxpmClient *nginxpm.Client) (*nginxpm.Certificate, error) {
-	log := log.FromContext(ctx)
-
-	if ph.Spec.Ssl == nil {
-		log.Info("SSL is not enabled, skipping certificate creation")
-		return nil, nil
-	}
-
-	domainsWithoutPorts := r.extractDomainsWithoutPorts(ph)
-	ssl := ph.Spec.Ssl
-
-	letsEncryptEmail := DEFAULT_EMAIL
-	if ssl.LetsEncryptEmail != nil && *ssl.LetsEncryptEmail != "" {
-		letsEncryptEmail = *ssl.LetsEncryptEmail
-	}
-
-	certificate, err := nginxpmClient.FindCertificateByDomain(domainsWithoutPorts)
-	if err != nil {
-		log.Error(err, "[autoCertificateRequest] Failed to find certificate by domain")
-		return nil, err
-	}
-
-	if certificate != nil {
-		log.Info("[autoCertificateRequest] Certificate found, applying to proxy host")
-	}
-
-	// If certificate is not found, we will create a new one
-	if certificate == nil {
-		log.Info("[autoCertificateRequest] Certificate not found, creating new certificate...")
-		lecCertificate, err := nginxpmClient.CreateLetEncryptCertificate(nginxpm.CreateLetEncryptCertificateRequest{
-			DomainNames: domainsWithoutPorts,
-			Meta: nginxpm.CreateLetEncryptCertificateRequestMeta{
-				DNSChallenge:     false,
-				LetsEncryptAgree: true,
-				LetsEncryptEmail: letsEncryptEmail,
-			},
-		})
+		certificate, err = controller.FindOrCreateCertificate(ctx, nginxpmClient, r.extractDomainsWithoutPorts(ph), ph.Spec.Ssl.LetsEncryptEmail)
 		if err != nil {
-			log.Error(err, "[autoCertificateRequest] Failed to create certificate")
 			return nil, err
 		}
-
-		certificate = &nginxpm.Certificate{
-			ID:          lecCertificate.ID,
-			CreatedOn:   lecCertificate.CreatedOn,
-			ModifiedOn:  lecCertificate.ModifiedOn,
-			Provider:    lecCertificate.Provider,
-			NiceName:    lecCertificate.NiceName,
-			DomainNames: lecCertificate.DomainNames,
-			ExpiresOn:   lecCertificate.ExpiresOn,
-		}
 	}
 
 	return certificate, nil
@@ -799,6 +845,11 @@ func (r *ProxyHostReconciler) extractDomainsWithoutPorts(ph *nginxpmoperatoriov1
 
 // ############################################# CONTROLLER ##############################################
 
+// isEnabled tells whether the proxy host must be enabled in NPM, it is when spec.enabled is not set
+func isEnabled(ph *nginxpmoperatoriov1.ProxyHost) bool {
+	return ph.Spec.Enabled == nil || *ph.Spec.Enabled
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ProxyHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Add the Token to the indexer
@@ -881,6 +932,25 @@ func (r *ProxyHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// Add the Secret of the access rules to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+
+		&nginxpmoperatoriov1.ProxyHost{},
+
+		PH_ACCESS_RULES_SECRET_FIELD,
+
+		func(rawObj client.Object) []string {
+			ph := rawObj.(*nginxpmoperatoriov1.ProxyHost)
+			if ph.Spec.AccessRules == nil || ph.Spec.AccessRules.AuthorizationsSecretRef == nil {
+				return nil
+			}
+
+			return []string{ph.Spec.AccessRules.AuthorizationsSecretRef.Name}
+		}); err != nil {
+		return err
+	}
+
 	// Add the Forward Service to the indexer
 	if err := mgr.GetFieldIndexer().IndexField(
 		context.Background(),
@@ -947,6 +1017,11 @@ func (r *ProxyHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(PH_TOKEN_FIELD)),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&nginxpmoperatoriov1.ClusterToken{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(PH_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Watches(
 			&nginxpmoperatoriov1.CustomCertificate{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(PH_CUSTOM_CERTIFICATE_FIELD)),
@@ -960,6 +1035,11 @@ func (r *ProxyHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(
 			&nginxpmoperatoriov1.AccessList{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(PH_ACCESS_LIST_FIELD)),
+			builder.WithPredicates(accessListIdChangedPredicate()),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(PH_ACCESS_RULES_SECRET_FIELD)),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
 		Watches(
@@ -976,6 +1056,25 @@ func (r *ProxyHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
+// accessListIdChangedPredicate only lets through the access lists whose NPM id changed,
+// the proxy hosts referencing them only need to be updated with the new id
+func accessListIdChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldAcl, ok := e.ObjectOld.(*nginxpmoperatoriov1.AccessList)
+			if !ok {
+				return false
+			}
+			newAcl, ok := e.ObjectNew.(*nginxpmoperatoriov1.AccessList)
+			if !ok {
+				return false
+			}
+
+			return !equality.Semantic.DeepEqual(oldAcl.Status.Id, newAcl.Status.Id)
+		},
+	}
+}
+
 func (r *ProxyHostReconciler) findObjectsForMap(field string) func(ctx context.Context, obj client.Object) []reconcile.Request {
 	return func(ctx context.Context, object client.Object) []reconcile.Request {
 		attachedObjects := &nginxpmoperatoriov1.ProxyHostList{}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redirectionhost
+
+import (
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// CaptureInitialConfiguration captures the initial state of an existing NPM redirection host
+// before any modifications are made by the operator
+func CaptureInitialConfiguration(redirectionHost *nginxpm.RedirectionHost) *nginxpmoperatoriov1.RedirectionHostInitialConfiguration {
+	if redirectionHost == nil {
+		return nil
+	}
+
+	config := &nginxpmoperatoriov1.RedirectionHostInitialConfiguration{
+		DomainNames:       redirectionHost.DomainNames,
+		ForwardScheme:     redirectionHost.ForwardScheme,
+		ForwardDomainName: redirectionHost.ForwardDomainName,
+		ForwardHttpCode:   redirectionHost.ForwardHTTPCode,
+		PreservePath:      redirectionHost.PreservePath,
+		SSLForced:         redirectionHost.SSLForced,
+		BlockExploits:     redirectionHost.BlockExploits,
+		HTTP2Support:      redirectionHost.HTTP2Support,
+		HSTSEnabled:       redirectionHost.HSTSEnabled,
+		HSTSSubdomains:    redirectionHost.HSTSSubdomains,
+		AdvancedConfig:    redirectionHost.AdvancedConfig,
+		Enabled:           redirectionHost.Enabled,
+	}
+
+	if redirectionHost.CertificateID != 0 {
+		certID := redirectionHost.CertificateID
+		config.CertificateId = &certID
+	}
+
+	return config
+}
+
+// BuildRestorationInput creates a RedirectionHostRequestInput from the stored initial configuration
+func BuildRestorationInput(config *nginxpmoperatoriov1.RedirectionHostInitialConfiguration) *nginxpm.RedirectionHostRequestInput {
+	if config == nil {
+		return nil
+	}
+
+	return &nginxpm.RedirectionHostRequestInput{
+		DomainNames:       config.DomainNames,
+		ForwardScheme:     config.ForwardScheme,
+		ForwardDomainName: config.ForwardDomainName,
+		ForwardHTTPCode:   config.ForwardHttpCode,
+		PreservePath:      config.PreservePath,
+		BlockExploits:     config.BlockExploits,
+		CertificateID:     config.CertificateId,
+		SSLForced:         config.SSLForced,
+		HTTP2Support:      config.HTTP2Support,
+		HSTSEnabled:       config.HSTSEnabled,
+		HSTSSubdomains:    config.HSTSSubdomains,
+		AdvancedConfig:    config.AdvancedConfig,
+	}
+}
+
+// ShouldCaptureInitialConfig reports whether we are binding to an existing
+// redirection host for the first time
+func ShouldCaptureInitialConfig(rh *nginxpmoperatoriov1.RedirectionHost, redirectionHost *nginxpm.RedirectionHost) bool {
+	return rh.Spec.BindExisting &&
+		redirectionHost != nil &&
+		rh.Status.InitialConfiguration == nil
+}
+
+// ShouldRestoreInitialConfig reports whether a bound resource has a stored
+// initial configuration to restore on deletion
+func ShouldRestoreInitialConfig(rh *nginxpmoperatoriov1.RedirectionHost) bool {
+	return rh.Status.Bound &&
+		rh.Status.InitialConfiguration != nil
+}
@@ -0,0 +1,626 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redirectionhost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+const (
+	redirectionHostFinalizer = "redirectionhost.nginxpm-operator.io/finalizers"
+
+	RH_TOKEN_FIELD = ".spec.token.name"
+
+	RH_CUSTOM_CERTIFICATE_FIELD = ".spec.ssl.customCertificate.name"
+
+	RH_LETSENCRYPT_CERTIFICATE_FIELD = ".spec.ssl.letsEncryptCertificate.name"
+)
+
+// RedirectionHostReconciler reconciles a RedirectionHost object
+type RedirectionHostReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=redirectionhosts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=redirectionhosts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=redirectionhosts/finalizers,verbs=update
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens/status,verbs=get
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=customcertificates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=customcertificates/status,verbs=get
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=letsencryptcertificates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=letsencryptcertificates/status,verbs=get
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// the RedirectionHost object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.0/pkg/reconcile
+func (r *RedirectionHostReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	rh := &nginxpmoperatoriov1.RedirectionHost{}
+
+	// Fetch the RedirectionHost instance
+	err := r.Get(ctx, req.NamespacedName, rh)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// If the custom resource is not found then it usually means that it was deleted or not created
+			// In this way, we will stop the reconciliation
+			log.Info("redirectionHost resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		log.Error(err, "Failed to get redirectionHost")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	isMarkedToBeDeleted := !rh.ObjectMeta.DeletionTimestamp.IsZero()
+
+	// Let's add a finalizer. Then, we can define some operations which should
+	// occur before the custom resource to be deleted.
+	if !isMarkedToBeDeleted {
+		if err := controller.AddFinalizer(r, ctx, redirectionHostFinalizer, rh); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+	}
+
+	// Leave the redirection host in NPM alone while the reconciliation is suspended,
+	// the finalizer is kept so a deletion waits until the resource is resumed
+	if controller.IsSuspended(rh, rh.Spec.Suspend) {
+		log.Info("Reconciliation is suspended, skipping")
+
+		controller.UpdateStatus(ctx, r.Client, rh, req.NamespacedName, func() {
+			controller.SetSuspendedCondition(&rh.Status.Conditions, rh.Generation, true)
+		})
+
+		return ctrl.Result{}, nil
+	}
+
+	// Let's just set the status as Unknown when no status is available
+	if len(rh.Status.Conditions) == 0 {
+		controller.UpdateStatus(ctx, r.Client, rh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&rh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionUnknown,
+				Type:               controller.ConditionTypeReconciling,
+				Reason:             "Reconciling",
+				Message:            "Starting reconciliation",
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+	}
+
+	// Create a new Nginx Proxy Manager client
+	nginxpmClient, tokenRef, err := controller.InitNginxPMClient(ctx, r, req, rh.Spec.Token)
+	if err != nil {
+		// Stop reconciliation if the resource is marked for deletion and the client can't be created
+		if isMarkedToBeDeleted {
+			// Re-fetch the resource to get the latest version before removing finalizer
+			latestRh := &nginxpmoperatoriov1.RedirectionHost{}
+			if err := r.Get(ctx, req.NamespacedName, latestRh); err != nil {
+				if apierrors.IsNotFound(err) {
+					return ctrl.Result{}, nil
+				}
+				log.Error(err, "Failed to re-fetch RedirectionHost before removing finalizer")
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, err
+			}
+
+			if err := controller.RemoveFinalizer(r, ctx, redirectionHostFinalizer, latestRh); err != nil {
+				if apierrors.IsConflict(err) {
+					log.Info("Conflict error when removing finalizer, will retry")
+					return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+				}
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+
+			return ctrl.Result{}, nil
+		}
+
+		r.Recorder.Event(
+			rh, "Warning", "InitNginxPMClient",
+			fmt.Sprintf("Failed to init nginxpm client: ResourceName: %s, Namespace: %s, err: %s",
+				req.Name, req.Namespace, err.Error()),
+		)
+
+		// Set the status as False when the client can't be created
+		controller.UpdateStatus(ctx, r.Client, rh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&rh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "InitNginxPMClient",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Delete the RedirectionHost record from remote Nginx Proxy Manager instance before deleting the resource
+	if isMarkedToBeDeleted {
+		if controllerutil.ContainsFinalizer(rh, redirectionHostFinalizer) {
+			log.Info("Performing Finalizer Operations for RedirectionHost")
+
+			if rh.Status.Id != nil {
+				deletionPolicy := controller.DeletionPolicy(rh.Spec.DeletionPolicy, rh.Status.Bound, ShouldRestoreInitialConfig(rh), controller.DELETION_POLICY_DISABLE)
+
+				switch deletionPolicy {
+				case controller.DELETION_POLICY_RESTORE:
+					log.Info("Restoring initial configuration for bound RedirectionHost", "redirectionHostId", *rh.Status.Id)
+
+					restorationInput := BuildRestorationInput(rh.Status.InitialConfiguration)
+					if restorationInput != nil {
+						_, err := nginxpmClient.UpdateRedirectionHostContext(ctx, *rh.Status.Id, *restorationInput)
+						if err != nil {
+							log.Error(err, "Failed to restore initial configuration for RedirectionHost")
+						} else {
+							log.Info("Successfully restored initial configuration for RedirectionHost")
+						}
+
+						if rh.Status.InitialConfiguration.Enabled {
+							err := nginxpmClient.EnableRedirectionHostContext(ctx, *rh.Status.Id)
+							if err != nil {
+								log.Error(err, "Failed to re-enable RedirectionHost after restoration")
+							}
+						}
+					}
+				case controller.DELETION_POLICY_DISABLE:
+					log.Info("Disabling RedirectionHost record from remote NPM")
+
+					err := nginxpmClient.DisableRedirectionHostContext(ctx, *rh.Status.Id)
+					if err != nil {
+						log.Error(err, "Failed to disable RedirectionHost record from remote NPM")
+					}
+				case controller.DELETION_POLICY_DELETE:
+					log.Info("Deleting RedirectionHost record from remote NPM")
+
+					err := nginxpmClient.DeleteRedirectionHostContext(ctx, *rh.Status.Id)
+					if err != nil {
+						log.Error(err, "Failed to delete RedirectionHost record from remote NPM")
+					}
+				default:
+					log.Info("Leaving RedirectionHost record in remote NPM", "deletionPolicy", deletionPolicy)
+				}
+			}
+
+			latestRh := &nginxpmoperatoriov1.RedirectionHost{}
+			if err := r.Get(ctx, req.NamespacedName, latestRh); err != nil {
+				if apierrors.IsNotFound(err) {
+					return ctrl.Result{}, nil
+				}
+
+				log.Error(err, "Failed to re-fetch RedirectionHost before removing finalizer")
+				return ctrl.Result{RequeueAfter: 10 * time.Second}, err
+			}
+
+			if err := controller.RemoveFinalizer(r, ctx, redirectionHostFinalizer, latestRh); err != nil {
+				if apierrors.IsConflict(err) {
+					log.Info("Conflict error when removing finalizer, will retry")
+					return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+				}
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Pin the token found by the fallback lookup, the resource keeps using it from now on
+	if err := controller.PinTokenReference(ctx, r.Client, rh, &rh.Spec.Token, tokenRef); err != nil {
+		log.Error(err, "Failed to pin the token reference of RedirectionHost")
+		return ctrl.Result{}, err
+	}
+
+	// Domains should be unique
+	if err := r.domainsShouldBeUnique(ctx, rh); err != nil {
+		controller.UpdateStatus(ctx, r.Client, rh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&rh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "DomainsShouldBeUnique",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Create or update redirection host
+	err = r.createOrUpdateRedirectionHost(ctx, req, rh, nginxpmClient)
+	if err != nil {
+		controller.UpdateStatus(ctx, r.Client, rh, req.NamespacedName, func() {
+			meta.SetStatusCondition(&rh.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "CreateOrUpdateRedirectionHost",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return controller.ResultForNginxPMError(nginxpmClient, err)
+	}
+
+	controller.UpdateStatus(ctx, r.Client, rh, req.NamespacedName, func() {
+		rh.Status.TokenRef = tokenRef
+		controller.SetSuspendedCondition(&rh.Status.Conditions, rh.Generation, false)
+		meta.SetStatusCondition(&rh.Status.Conditions, metav1.Condition{
+			Status:             metav1.ConditionTrue,
+			Type:               controller.ConditionTypeReady,
+			Reason:             "CreateOrUpdateRedirectionHost",
+			Message:            fmt.Sprintf("Redirection host created or updated, ResourceName: %s", req.Name),
+			LastTransitionTime: metav1.Now(),
+		})
+	})
+
+	return ctrl.Result{}, nil
+}
+
+func (r *RedirectionHostReconciler) domainsShouldBeUnique(ctx context.Context, rh *nginxpmoperatoriov1.RedirectionHost) error {
+	log := log.FromContext(ctx)
+
+	redirectionHosts := &nginxpmoperatoriov1.RedirectionHostList{}
+	if err := r.List(ctx, redirectionHosts); err != nil {
+		log.Error(err, "Failed to list redirection hosts")
+		return err
+	}
+
+	domains := extractDomains(rh)
+
+	for _, redirectionHost := range redirectionHosts.Items {
+		if redirectionHost.GetName() == rh.GetName() && redirectionHost.GetNamespace() == rh.GetNamespace() {
+			continue
+		}
+
+		redirectionHostDomains := extractDomains(&redirectionHost)
+		for _, domain := range domains {
+			if slices.Contains(redirectionHostDomains, domain) {
+				msg := fmt.Sprintf("Domain %s is already used by another redirection host: (name: %s, namespace: %s)",
+					domain, redirectionHost.GetName(), redirectionHost.GetNamespace())
+
+				err := errors.New(msg)
+				log.Error(err, msg)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *RedirectionHostReconciler) createOrUpdateRedirectionHost(ctx context.Context, req ctrl.Request, rh *nginxpmoperatoriov1.RedirectionHost, nginxpmClient *nginxpm.Client) error {
+	log := log.FromContext(ctx)
+
+	var redirectionHost *nginxpm.RedirectionHost
+	var err error
+
+	bound := rh.Status.Bound
+	domains := extractDomains(rh)
+
+	var capturedInitialConfig *nginxpmoperatoriov1.RedirectionHostInitialConfiguration
+
+	// Let's check if the redirection host is already created
+	if rh.Status.Id != nil {
+		redirectionHost, err = nginxpmClient.FindRedirectionHostByIDContext(ctx, *rh.Status.Id)
+		if err != nil {
+			r.Recorder.Event(
+				rh, "Warning", "FindRedirectionHostByID",
+				fmt.Sprintf("Failed to find redirection host by ID, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to find redirection host by ID")
+			return err
+		}
+
+		// Preserve existing initial configuration
+		capturedInitialConfig = rh.Status.InitialConfiguration
+
+	} else if rh.Spec.BindExisting {
+		// If finding by ID doesn't match a record, we search for the redirection host by domain.
+		redirectionHost, _ = nginxpmClient.FindRedirectionHostByDomainContext(ctx, domains)
+
+		if redirectionHost != nil {
+			bound = true
+
+			if ShouldCaptureInitialConfig(rh, redirectionHost) {
+				capturedInitialConfig = CaptureInitialConfiguration(redirectionHost)
+				log.Info("Captured initial configuration for bound redirection host", "redirectionHostId", redirectionHost.ID)
+			}
+		}
+	}
+
+	// Enable RedirectionHost if disabled
+	if redirectionHost != nil && !redirectionHost.Enabled {
+		log.Info("Enabling RedirectionHost")
+		nginxpmClient.EnableRedirectionHostContext(ctx, redirectionHost.ID)
+	}
+
+	// Certificate operation
+	var certificateID *int
+	if rh.Spec.Ssl != nil {
+		certificate, err := r.makeCertificate(ctx, req, rh, nginxpmClient)
+		if err != nil {
+			r.Recorder.Event(
+				rh, "Warning", "MakeCertificate",
+				fmt.Sprintf("Failed to make certificate, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+			return err
+		}
+
+		if certificate != nil {
+			certificateID = &certificate.ID
+		}
+	}
+
+	input := nginxpm.RedirectionHostRequestInput{
+		DomainNames:       domains,
+		ForwardScheme:     rh.Spec.Forward.Scheme,
+		ForwardDomainName: rh.Spec.Forward.DomainName,
+		ForwardHTTPCode:   rh.Spec.Forward.HttpCode,
+		PreservePath:      rh.Spec.Forward.PreservePath,
+		BlockExploits:     rh.Spec.BlockExploits,
+		AdvancedConfig:    rh.Spec.AdvancedConfig,
+		CertificateID:     certificateID,
+	}
+
+	// Handle SSL fields
+	if rh.Spec.Ssl != nil {
+		input.SSLForced = rh.Spec.Ssl.SslForced
+		input.HTTP2Support = rh.Spec.Ssl.Http2Support
+		input.HSTSEnabled = rh.Spec.Ssl.HstsEnabled
+		input.HSTSSubdomains = rh.Spec.Ssl.HstsSubdomains
+	}
+
+	if redirectionHost != nil {
+		redirectionHost, err = nginxpmClient.UpdateRedirectionHostContext(ctx, redirectionHost.ID, input)
+		if err != nil {
+			r.Recorder.Event(
+				rh, "Warning", "UpdateRedirectionHost",
+				fmt.Sprintf("Failed to update redirection host, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to update redirection host")
+			return err
+		}
+
+		log.Info("RedirectionHost updated successfully")
+	} else {
+		redirectionHost, err = nginxpmClient.CreateRedirectionHostContext(ctx, input)
+		if err != nil {
+			r.Recorder.Event(
+				rh, "Warning", "CreateRedirectionHost",
+				fmt.Sprintf("Failed to create redirection host, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to create redirection host")
+			return err
+		}
+
+		log.Info("RedirectionHost created successfully")
+	}
+
+	return controller.UpdateStatus(ctx, r.Client, rh, req.NamespacedName, func() {
+		rh.Status.Id = &redirectionHost.ID
+		rh.Status.Online = redirectionHost.Meta.NginxOnline
+		rh.Status.CertificateId = certificateID
+		rh.Status.Bound = bound
+		if capturedInitialConfig != nil {
+			rh.Status.InitialConfiguration = capturedInitialConfig
+		}
+	})
+}
+
+// ############################################# CERTIFICATE OPERATION ##############################################
+
+func (r *RedirectionHostReconciler) makeCertificate(ctx context.Context, req ctrl.Request, rh *nginxpmoperatoriov1.RedirectionHost, nginxpmClient *nginxpm.Client) (*nginxpm.Certificate, error) {
+	log := log.FromContext(ctx)
+
+	if rh.Spec.Ssl == nil {
+		log.Info("SSL is not enabled, skipping certificate operation")
+		return nil, nil
+	}
+
+	certificate, err := controller.RetrieveCertificate(controller.RetrieveCertificateOption{
+		Cxt:                    ctx,
+		Req:                    req,
+		Reader:                 r,
+		NginxpmClient:          nginxpmClient,
+		LetsEncryptCertificate: rh.Spec.Ssl.LetsEncryptCertificate,
+		CustomCertificate:      rh.Spec.Ssl.CustomCertificate,
+		CertificateId:          rh.Spec.Ssl.CertificateId,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rh.Spec.Ssl.AutoCertificateRequest && certificate == nil {
+		log.Info("Since no LetsEncryptCertificate, CustomCertificate, or CertificateId is provided, AutoCertificateRequest is enabled, finding or creating certificate")
+		certificate, err = controller.FindOrCreateCertificate(ctx, nginxpmClient, extractDomains(rh), rh.Spec.Ssl.LetsEncryptEmail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return certificate, nil
+}
+
+// ############################################# UTILS ##############################################
+
+func extractDomains(rh *nginxpmoperatoriov1.RedirectionHost) []string {
+	domains := make([]string, len(rh.Spec.DomainNames))
+	for i, domain := range rh.Spec.DomainNames {
+		domains[i] = string(domain)
+	}
+
+	return domains
+}
+
+// ############################################# CONTROLLER ##############################################
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RedirectionHostReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Add the Token to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+
+		&nginxpmoperatoriov1.RedirectionHost{},
+
+		RH_TOKEN_FIELD,
+
+		func(rawObj client.Object) []string {
+			rh := rawObj.(*nginxpmoperatoriov1.RedirectionHost)
+
+			if rh.Spec.Token == nil {
+				// If token is not provided, use the default token name
+				return []string{controller.TOKEN_DEFAULT_NAME}
+			}
+
+			if rh.Spec.Token.Name == "" {
+				return nil
+			}
+
+			return []string{rh.Spec.Token.Name}
+		}); err != nil {
+		return err
+	}
+
+	// Add the CustomCertificate to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+
+		&nginxpmoperatoriov1.RedirectionHost{},
+
+		RH_CUSTOM_CERTIFICATE_FIELD,
+
+		func(rawObj client.Object) []string {
+			rh := rawObj.(*nginxpmoperatoriov1.RedirectionHost)
+			if rh.Spec.Ssl == nil || rh.Spec.Ssl.CustomCertificate == nil || rh.Spec.Ssl.CustomCertificate.Name == "" {
+				return nil
+			}
+			return []string{rh.Spec.Ssl.CustomCertificate.Name}
+		}); err != nil {
+		return err
+	}
+
+	// Add the LetsEncryptCertificate to the indexer
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+
+		&nginxpmoperatoriov1.RedirectionHost{},
+
+		RH_LETSENCRYPT_CERTIFICATE_FIELD,
+
+		func(rawObj client.Object) []string {
+			rh := rawObj.(*nginxpmoperatoriov1.RedirectionHost)
+			if rh.Spec.Ssl == nil || rh.Spec.Ssl.LetsEncryptCertificate == nil || rh.Spec.Ssl.LetsEncryptCertificate.Name == "" {
+				return nil
+			}
+			return []string{rh.Spec.Ssl.LetsEncryptCertificate.Name}
+		}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&nginxpmoperatoriov1.RedirectionHost{}).
+		Owns(&nginxpmoperatoriov1.Token{}).
+		Owns(&nginxpmoperatoriov1.CustomCertificate{}).
+		Owns(&nginxpmoperatoriov1.LetsEncryptCertificate{}).
+		Watches(
+			&nginxpmoperatoriov1.Token{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(RH_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&nginxpmoperatoriov1.ClusterToken{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(RH_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&nginxpmoperatoriov1.CustomCertificate{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(RH_CUSTOM_CERTIFICATE_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Watches(
+			&nginxpmoperatoriov1.LetsEncryptCertificate{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(RH_LETSENCRYPT_CERTIFICATE_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Named("redirectionhost").
+		Complete(r)
+}
+
+func (r *RedirectionHostReconciler) findObjectsForMap(field string) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, object client.Object) []reconcile.Request {
+		attachedObjects := &nginxpmoperatoriov1.RedirectionHostList{}
+
+		listOps := &client.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(field, object.GetName()),
+		}
+
+		err := r.List(ctx, attachedObjects, listOps)
+		if err != nil {
+			return []reconcile.Request{}
+		}
+
+		requests := make([]reconcile.Request, len(attachedObjects.Items))
+		for i, item := range attachedObjects.Items {
+			requests[i] = reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      item.GetName(),
+					Namespace: item.GetNamespace(),
+				},
+			}
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redirectionhost
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/test/fakenpm"
+)
+
+const collection = "redirection-hosts"
+
+func newReconciler(t *testing.T, server *fakenpm.Server, rh *nginxpmoperatoriov1.RedirectionHost) *RedirectionHostReconciler {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = nginxpmoperatoriov1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(server.Token(controller.TOKEN_DEFAULT_NAME, rh.Namespace), rh)...).
+		WithStatusSubresource(rh).
+		Build()
+
+	return &RedirectionHostReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+}
+
+func reconcileRedirectionHost(t *testing.T, r *RedirectionHostReconciler, rh *nginxpmoperatoriov1.RedirectionHost) {
+	t.Helper()
+
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rh)}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Unexpected reconcile error: %v", err)
+	}
+
+	if err := r.Get(context.Background(), req.NamespacedName, rh); err != nil && !apierrors.IsNotFound(err) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func deleteRedirectionHost(t *testing.T, r *RedirectionHostReconciler, rh *nginxpmoperatoriov1.RedirectionHost) {
+	t.Helper()
+
+	if err := r.Delete(context.Background(), rh); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reconcileRedirectionHost(t, r, rh)
+
+	err := r.Get(context.Background(), types.NamespacedName{Name: rh.Name, Namespace: rh.Namespace}, &nginxpmoperatoriov1.RedirectionHost{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected the finalizer to be removed, got %v", err)
+	}
+}
+
+func TestReconcileBindsAndRestores(t *testing.T) {
+	server := fakenpm.NewServer()
+	defer server.Close()
+
+	id := server.Add(collection, map[string]interface{}{
+		"domain_names":        []string{"old.example.com"},
+		"forward_scheme":      "http",
+		"forward_domain_name": "legacy.example.com",
+		"forward_http_code":   301,
+		"enabled":             true,
+	})
+
+	rh := &nginxpmoperatoriov1.RedirectionHost{
+		ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default"},
+		Spec: nginxpmoperatoriov1.RedirectionHostSpec{
+			DomainNames:  []nginxpmoperatoriov1.DomainName{"old.example.com"},
+			BindExisting: true,
+			Forward:      nginxpmoperatoriov1.RedirectionHostForward{Scheme: "https", DomainName: "new.example.com", HttpCode: 302},
+		},
+	}
+
+	r := newReconciler(t, server, rh)
+	reconcileRedirectionHost(t, r, rh)
+
+	if rh.Status.Id == nil || *rh.Status.Id != id || !rh.Status.Bound {
+		t.Fatalf("Expected the existing redirection host %d to be bound, got %+v", id, rh.Status)
+	}
+
+	initial := rh.Status.InitialConfiguration
+	if initial == nil || initial.ForwardDomainName != "legacy.example.com" || initial.ForwardHttpCode != 301 {
+		t.Fatalf("Expected the initial configuration to be captured, got %+v", initial)
+	}
+
+	if forward := server.Get(collection, id)["forward_domain_name"]; forward != "new.example.com" {
+		t.Errorf("Expected the bound redirection host to be updated from the spec, got %v", forward)
+	}
+
+	// A second reconcile keeps the configuration captured when the redirection host was bound
+	reconcileRedirectionHost(t, r, rh)
+	if rh.Status.InitialConfiguration.ForwardDomainName != "legacy.example.com" {
+		t.Errorf("Expected the initial configuration to be kept, got %+v", rh.Status.InitialConfiguration)
+	}
+
+	deleteRedirectionHost(t, r, rh)
+
+	restored := server.Get(collection, id)
+	if restored == nil {
+		t.Fatal("Expected the bound redirection host to be kept in NPM")
+	}
+	if restored["forward_domain_name"] != "legacy.example.com" || restored["enabled"] != true {
+		t.Errorf("Expected the initial configuration to be restored, got %v", restored)
+	}
+}
+
+func TestReconcileDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		exists  bool
+		enabled bool
+	}{
+		{"", false, false},
+		{controller.DELETION_POLICY_DISABLE, true, false},
+		{controller.DELETION_POLICY_ORPHAN, true, true},
+		// Nothing was captured to restore, the redirection host is disabled rather than deleted
+		{controller.DELETION_POLICY_RESTORE, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("policy "+tt.policy, func(t *testing.T) {
+			server := fakenpm.NewServer()
+			defer server.Close()
+
+			rh := &nginxpmoperatoriov1.RedirectionHost{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec: nginxpmoperatoriov1.RedirectionHostSpec{
+					DomainNames:    []nginxpmoperatoriov1.DomainName{"app.example.com"},
+					DeletionPolicy: tt.policy,
+					Forward:        nginxpmoperatoriov1.RedirectionHostForward{Scheme: "auto", DomainName: "www.example.com", HttpCode: 301},
+				},
+			}
+
+			r := newReconciler(t, server, rh)
+			reconcileRedirectionHost(t, r, rh)
+
+			if rh.Status.Id == nil || rh.Status.Bound {
+				t.Fatalf("Expected a redirection host to be created, got %+v", rh.Status)
+			}
+			id := *rh.Status.Id
+
+			if rh.Spec.Token == nil || rh.Spec.Token.Name != controller.TOKEN_DEFAULT_NAME {
+				t.Errorf("Expected the default token to be pinned, got %+v", rh.Spec.Token)
+			}
+
+			deleteRedirectionHost(t, r, rh)
+
+			object := server.Get(collection, id)
+			if (object != nil) != tt.exists {
+				t.Fatalf("Expected the redirection host to exist in NPM: %v, got %v", tt.exists, object)
+			}
+			if object != nil && object["enabled"] != tt.enabled {
+				t.Errorf("Expected the redirection host to be enabled: %v, got %v", tt.enabled, object["enabled"])
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stream
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// DiffStream compares the live stream with the input built from the spec,
+// it returns one "field: expected X, found Y" entry per field which differs.
+func DiffStream(live *nginxpm.Stream, input nginxpm.StreamRequestInput) []string {
+	var diff []string
+
+	compare := func(field string, expected, found interface{}) {
+		if expected != found {
+			diff = append(diff, fmt.Sprintf("%s: expected %v, found %v", field, expected, found))
+		}
+	}
+
+	compare("incoming_port", input.IncomingPort, live.IncomingPort)
+	compare("forwarding_host", input.ForwardingHost, live.ForwardingHost)
+	compare("forwarding_port", input.ForwardingPort, live.ForwardingPort)
+	compare("tcp_forwarding", input.TCPForwarding, live.TCPForwarding)
+	compare("udp_forwarding", input.UDPForwarding, live.UDPForwarding)
+	compare("certificate_id", input.CertificateID, live.CertificateID)
+
+	// The operator keeps its streams enabled, the input does not carry the field
+	compare("enabled", true, live.Enabled)
+
+	if custom, ok := input.CustomFields[nginxpm.CUSTOM_FIELD_UNSCOPED_CONFIG]; ok && custom.Allowed && live.UnscopedConfig != nil {
+		if strings.TrimSpace(custom.Value) != strings.TrimSpace(*live.UnscopedConfig) {
+			diff = append(diff, fmt.Sprintf("%s: changed", nginxpm.CUSTOM_FIELD_UNSCOPED_CONFIG))
+		}
+	}
+
+	return diff
+}
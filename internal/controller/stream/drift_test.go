@@ -0,0 +1,38 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stream
+
+import (
+	"testing"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+func TestDiffStream(t *testing.T) {
+	input := nginxpm.StreamRequestInput{IncomingPort: 5432, ForwardingHost: "10.0.0.1", ForwardingPort: 5432, TCPForwarding: true}
+	live := &nginxpm.Stream{IncomingPort: 5432, ForwardingHost: "10.0.0.1", ForwardingPort: 5432, TCPForwarding: true, Enabled: true}
+
+	if diff := DiffStream(live, input); len(diff) != 0 {
+		t.Errorf("Expected no drift, got %v", diff)
+	}
+
+	// A stream disabled in NPM is reported
+	live.Enabled = false
+	if diff := DiffStream(live, input); len(diff) != 1 || diff[0] != "enabled: expected true, found false" {
+		t.Errorf("Expected the disabled stream to be reported, got %v", diff)
+	}
+}
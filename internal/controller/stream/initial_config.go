@@ -0,0 +1,85 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stream
+
+import (
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// CaptureInitialConfiguration captures the initial state of an existing NPM stream
+// before any modifications are made by the operator
+func CaptureInitialConfiguration(stream *nginxpm.Stream) *nginxpmoperatoriov1.StreamInitialConfiguration {
+	if stream == nil {
+		return nil
+	}
+
+	config := &nginxpmoperatoriov1.StreamInitialConfiguration{
+		IncomingPort:   stream.IncomingPort,
+		ForwardingHost: stream.ForwardingHost,
+		ForwardingPort: stream.ForwardingPort,
+		TCPForwarding:  stream.TCPForwarding,
+		UDPForwarding:  stream.UDPForwarding,
+		Enabled:        stream.Enabled,
+	}
+
+	// Capture certificate ID if present
+	if stream.CertificateID != 0 {
+		certID := stream.CertificateID
+		config.CertificateId = &certID
+	}
+
+	return config
+}
+
+// BuildRestorationInput creates a StreamRequestInput from the stored initial configuration
+// to restore the original settings when a bound resource is deleted
+func BuildRestorationInput(config *nginxpmoperatoriov1.StreamInitialConfiguration) *nginxpm.StreamRequestInput {
+	if config == nil {
+		return nil
+	}
+
+	input := &nginxpm.StreamRequestInput{
+		IncomingPort:   config.IncomingPort,
+		ForwardingHost: config.ForwardingHost,
+		ForwardingPort: config.ForwardingPort,
+		TCPForwarding:  config.TCPForwarding,
+		UDPForwarding:  config.UDPForwarding,
+		CustomFields:   make(nginxpm.RequestCustomFields),
+	}
+
+	if config.CertificateId != nil {
+		input.CertificateID = *config.CertificateId
+	}
+
+	return input
+}
+
+// ShouldCaptureInitialConfig determines if we should capture the initial configuration
+// This happens when binding to an existing stream for the first time
+func ShouldCaptureInitialConfig(st *nginxpmoperatoriov1.Stream, stream *nginxpm.Stream) bool {
+	return st.Spec.BindExisting &&
+		stream != nil &&
+		st.Status.InitialConfiguration == nil
+}
+
+// ShouldRestoreInitialConfig determines if we should restore the initial configuration
+// This happens when deleting a bound resource that has stored initial configuration
+func ShouldRestoreInitialConfig(st *nginxpmoperatoriov1.Stream) bool {
+	return st.Status.Bound &&
+		st.Status.InitialConfiguration != nil
+}
@@ -23,7 +23,9 @@ type MakeForwardOption struct {
 	UnscopedConfigSupported bool
 }
 
-func (r *StreamReconciler) makeForward(option MakeForwardOption) (*StreamForward, error) {
+// MakeForward returns the address the stream traffic is forwarded to,
+// taken from the spec hosts or from the referenced Service.
+func (r *StreamReconciler) MakeForward(option MakeForwardOption) (*StreamForward, error) {
 	log := log.FromContext(option.Ctx)
 
 	req := option.Req
@@ -19,6 +19,7 @@ package stream
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -116,6 +117,18 @@ func (r *StreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	}
 
+	// Leave the stream in NPM alone while the reconciliation is suspended,
+	// the finalizer is kept so a deletion waits until the resource is resumed
+	if controller.IsSuspended(st, st.Spec.Suspend) {
+		log.Info("Reconciliation is suspended, skipping")
+
+		controller.UpdateStatus(ctx, r.Client, st, req.NamespacedName, func() {
+			controller.SetSuspendedCondition(&st.Status.Conditions, st.Generation, true)
+		})
+
+		return ctrl.Result{}, nil
+	}
+
 	// Let's just set the status as Unknown when no status is available
 	if len(st.Status.Conditions) == 0 {
 		controller.UpdateStatus(ctx, r.Client, st, req.NamespacedName, func() {
@@ -130,7 +143,7 @@ func (r *StreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	}
 
 	// Create a new Nginx Proxy Manager client
-	nginxpmClient, err := controller.InitNginxPMClient(ctx, r, req, st.Spec.Token)
+	nginxpmClient, tokenRef, err := controller.InitNginxPMClient(ctx, r, req, st.Spec.Token)
 	if err != nil {
 		if isMarkedToBeDeleted {
 			if err := controller.RemoveFinalizer(r, ctx, streamFinalizer, st); err != nil {
@@ -165,10 +178,38 @@ func (r *StreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			log.Info("Performing Finalizer Operations for Stream")
 
 			if st.Status.Id != nil {
-				// Delete stream here
-				err := nginxpmClient.DeleteStream(int(*st.Status.Id))
-				if err != nil {
-					log.Error(err, "Failed to delete stream from remote NPM")
+				deletionPolicy := controller.DeletionPolicy(st.Spec.DeletionPolicy, st.Status.Bound, ShouldRestoreInitialConfig(st), controller.DELETION_POLICY_DISABLE)
+
+				switch deletionPolicy {
+				case controller.DELETION_POLICY_RESTORE:
+					log.Info("Restoring initial configuration for bound Stream", "streamId", *st.Status.Id)
+
+					restorationInput := BuildRestorationInput(st.Status.InitialConfiguration)
+					_, err := nginxpmClient.UpdateStreamContext(ctx, *st.Status.Id, *restorationInput)
+					if err != nil {
+						log.Error(err, "Failed to restore initial configuration for Stream")
+					}
+
+					// The stream is enabled by the operator when it is bound, disable it if it was disabled
+					if !st.Status.InitialConfiguration.Enabled {
+						if err := nginxpmClient.DisableStreamContext(ctx, *st.Status.Id); err != nil {
+							log.Error(err, "Failed to disable Stream after restoration")
+						}
+					}
+				case controller.DELETION_POLICY_DISABLE:
+					log.Info("Disabling stream from remote NPM")
+
+					err := nginxpmClient.DisableStreamContext(ctx, *st.Status.Id)
+					if err != nil {
+						log.Error(err, "Failed to disable stream from remote NPM")
+					}
+				case controller.DELETION_POLICY_DELETE:
+					err := nginxpmClient.DeleteStreamContext(ctx, int(*st.Status.Id))
+					if err != nil {
+						log.Error(err, "Failed to delete stream from remote NPM")
+					}
+				default:
+					log.Info("Leaving stream in remote NPM", "deletionPolicy", deletionPolicy)
 				}
 			}
 
@@ -181,6 +222,12 @@ func (r *StreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, nil
 	}
 
+	// Pin the token found by the fallback lookup, the resource keeps using it from now on
+	if err := controller.PinTokenReference(ctx, r.Client, st, &st.Spec.Token, tokenRef); err != nil {
+		log.Error(err, "Failed to pin the token reference of Stream")
+		return ctrl.Result{}, err
+	}
+
 	// Create or update stream
 	err = r.createOrUpdateStream(ctx, req, st, nginxpmClient)
 	if err != nil {
@@ -195,11 +242,13 @@ func (r *StreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			})
 		})
 
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return controller.ResultForNginxPMError(nginxpmClient, err)
 	}
 
 	// Set the status as True when the client can be created
 	controller.UpdateStatus(ctx, r.Client, st, req.NamespacedName, func() {
+		st.Status.TokenRef = tokenRef
+		controller.SetSuspendedCondition(&st.Status.Conditions, st.Generation, false)
 		meta.SetStatusCondition(&st.Status.Conditions, metav1.Condition{
 			Status:             metav1.ConditionTrue,
 			Type:               controller.ConditionTypeReady,
@@ -209,7 +258,7 @@ func (r *StreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		})
 	})
 
-	return ctrl.Result{}, nil
+	return controller.ResyncResult(), nil
 }
 
 func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Request, st *nginxpmoperatoriov1.Stream, nginxpmClient *nginxpm.Client) error {
@@ -218,8 +267,14 @@ func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Re
 	var stream *nginxpm.Stream
 	var err error
 
+	bound := st.Status.Bound
+	justBound := false
+
+	// Preserve existing initial configuration
+	capturedInitialConfig := st.Status.InitialConfiguration
+
 	if st.Status.Id != nil {
-		stream, err = nginxpmClient.FindStreamByID(*st.Status.Id)
+		stream, err = nginxpmClient.FindStreamByIDContext(ctx, *st.Status.Id)
 		if err != nil {
 			r.Recorder.Event(
 				st, "Warning", "FindStreamByID",
@@ -233,7 +288,7 @@ func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Re
 	}
 
 	// Stream forward operation
-	streamForward, err := r.makeForward(MakeForwardOption{
+	streamForward, err := r.MakeForward(MakeForwardOption{
 		Ctx:                     ctx,
 		Req:                     req,
 		Stream:                  st,
@@ -281,6 +336,51 @@ func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Re
 		incomingPort = streamForward.Port
 	}
 
+	// Bind the stream already listening on the incoming port
+	if stream == nil && st.Spec.BindExisting {
+		stream, err = nginxpmClient.FindStreamByIncomingPortContext(ctx, incomingPort)
+		if err != nil {
+			r.Recorder.Event(
+				st, "Warning", "FindStreamByIncomingPort",
+				fmt.Sprintf("Failed to find stream by incoming port, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to find stream by incoming port")
+			return err
+		}
+
+		if stream != nil {
+			bound = true
+			justBound = true
+
+			// Capture initial configuration if we haven't already
+			if ShouldCaptureInitialConfig(st, stream) {
+				capturedInitialConfig = CaptureInitialConfiguration(stream)
+				log.Info("Captured initial configuration for bound stream", "streamId", stream.ID)
+			}
+		}
+	}
+
+	// Enable the stream if it is disabled. A stream created by the operator is always enabled again,
+	// a bound stream disabled later in NPM only with the Correct drift policy
+	correctDrift := controller.DriftPolicy(st.Spec.DriftPolicy) == controller.DRIFT_POLICY_CORRECT
+	if stream != nil && !stream.Enabled && (!bound || justBound || correctDrift) {
+		log.Info("Enabling Stream")
+		if err := nginxpmClient.EnableStreamContext(ctx, stream.ID); err != nil {
+			r.Recorder.Event(
+				st, "Warning", "EnableStream",
+				fmt.Sprintf("Failed to enable stream, ResourceName: %s, Namespace: %s, err: %s",
+					req.Name, req.Namespace, err.Error()),
+			)
+
+			log.Error(err, "Failed to enable stream")
+			return err
+		}
+
+		stream.Enabled = true
+	}
+
 	input := nginxpm.StreamRequestInput{
 		IncomingPort:   incomingPort,
 		ForwardingHost: streamForward.Host,
@@ -316,9 +416,32 @@ func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Re
 
 	allCustomFieldsSupported := withCustomFields(stream, &input)
 
-	// Update stream
-	if stream != nil {
-		stream, err = nginxpmClient.UpdateStream(stream.ID, input)
+	// Look for changes made outside of the operator, the stream is updated
+	// when the configuration built from the spec is not the one applied last
+	configHash := controller.HashConfig(input)
+	driftPolicy := controller.DriftPolicy(st.Spec.DriftPolicy)
+
+	drift, upToDate := controller.CheckDrift(driftPolicy, stream != nil, st.Status.AppliedConfigHash, configHash, func() []string {
+		return DiffStream(stream, input)
+	})
+
+	if len(drift) > 0 {
+		log.Info("Stream drifted from its spec", "drift", drift, "driftPolicy", driftPolicy)
+
+		if controller.DriftChanged(st.Status.Conditions, driftPolicy, drift) {
+			r.Recorder.Event(
+				st, "Warning", "DriftDetected",
+				fmt.Sprintf("Stream changed outside of the operator, ResourceName: %s, Namespace: %s, drift: %s",
+					req.Name, req.Namespace, strings.Join(drift, "; ")),
+			)
+		}
+	}
+
+	if upToDate {
+		log.Info("Stream is up to date")
+	} else if stream != nil {
+		// Update stream
+		stream, err = nginxpmClient.UpdateStreamContext(ctx, stream.ID, input)
 		if err != nil {
 			r.Recorder.Event(
 				st, "Warning", "UpdateStream",
@@ -333,7 +456,7 @@ func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Re
 		log.Info("Stream updated successfully")
 	} else {
 		// Create stream
-		stream, err = nginxpmClient.CreateStream(input)
+		stream, err = nginxpmClient.CreateStreamContext(ctx, input)
 		if err != nil {
 			r.Recorder.Event(
 				st, "Warning", "CreateStream",
@@ -348,7 +471,7 @@ func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Re
 		// In case not all custom field supported, we send update request
 		if !allCustomFieldsSupported {
 			withCustomFields(stream, &input) // call withCustomFields again to ensure all custom fields are supported
-			nginxpmClient.UpdateStream(stream.ID, input)
+			nginxpmClient.UpdateStreamContext(ctx, stream.ID, input)
 		}
 
 		log.Info("Stream created successfully")
@@ -359,6 +482,10 @@ func (r *StreamReconciler) createOrUpdateStream(ctx context.Context, req ctrl.Re
 		st.Status.Online = stream.Meta.NginxOnline
 		st.Status.IncomingPort = &incomingPort
 		st.Status.ForwardingPort = &streamForward.Port
+		st.Status.AppliedConfigHash = configHash
+		st.Status.Bound = bound
+		st.Status.InitialConfiguration = capturedInitialConfig
+		controller.SetDriftedCondition(&st.Status.Conditions, st.Generation, driftPolicy, drift)
 	})
 
 }
@@ -455,6 +582,11 @@ func (r *StreamReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(ST_TOKEN_FIELD)),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&nginxpmoperatoriov1.ClusterToken{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(ST_TOKEN_FIELD)),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Watches(
 			&nginxpmoperatoriov1.CustomCertificate{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForMap(ST_CUSTOM_CERTIFICATE_FIELD)),
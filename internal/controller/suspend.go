@@ -0,0 +1,54 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PAUSED_ANNOTATION suspends the reconciliation of a resource when set to "true", like its spec.suspend
+const PAUSED_ANNOTATION = "nginxpm-operator.io/paused"
+
+// IsSuspended tells whether the reconciliation of the object is suspended by its spec or its annotation
+func IsSuspended(object metav1.Object, suspend bool) bool {
+	return suspend || object.GetAnnotations()[PAUSED_ANNOTATION] == "true"
+}
+
+// SetSuspendedCondition records whether the reconciliation is suspended,
+// the condition is only added once the resource gets suspended and is kept as False on resume
+func SetSuspendedCondition(conditions *[]metav1.Condition, generation int64, suspended bool) {
+	if !suspended && meta.FindStatusCondition(*conditions, ConditionTypeSuspended) == nil {
+		return
+	}
+
+	condition := metav1.Condition{
+		Status:             metav1.ConditionFalse,
+		Type:               ConditionTypeSuspended,
+		Reason:             "Resumed",
+		Message:            "Reconciliation is running",
+		ObservedGeneration: generation,
+	}
+
+	if suspended {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Suspended"
+		condition.Message = "Reconciliation is suspended, the object in Nginx Proxy Manager is left as is"
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSuspend(t *testing.T) {
+	object := &metav1.ObjectMeta{}
+
+	if IsSuspended(object, false) {
+		t.Error("Expected the object not to be suspended")
+	}
+
+	object.Annotations = map[string]string{PAUSED_ANNOTATION: "true"}
+
+	if !IsSuspended(object, false) {
+		t.Error("Expected the paused annotation to suspend the object")
+	}
+
+	var conditions []metav1.Condition
+
+	SetSuspendedCondition(&conditions, 1, false)
+	if len(conditions) != 0 {
+		t.Errorf("Expected no condition before the object is suspended, got %v", conditions)
+	}
+
+	SetSuspendedCondition(&conditions, 1, true)
+	if !meta.IsStatusConditionTrue(conditions, ConditionTypeSuspended) {
+		t.Error("Expected the Suspended condition to be true")
+	}
+
+	SetSuspendedCondition(&conditions, 2, false)
+	if !meta.IsStatusConditionFalse(conditions, ConditionTypeSuspended) {
+		t.Error("Expected the Suspended condition to be false once resumed")
+	}
+}
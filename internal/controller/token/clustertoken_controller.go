@@ -0,0 +1,219 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logger "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+)
+
+// ClusterTokenReconciler reconciles a ClusterToken object
+type ClusterTokenReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// RefreshRatio is the fraction of the token lifetime after which it is refreshed,
+	// DEFAULT_TOKEN_REFRESH_RATIO when not set
+	RefreshRatio float64
+}
+
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=clustertokens,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=clustertokens/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=nginxpm-operator.io,resources=clustertokens/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile issues the token of a ClusterToken from the credentials of its secret,
+// which lives in the namespace of the operator.
+func (r *ClusterTokenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logger.FromContext(ctx)
+
+	clusterToken := &nginxpmoperatoriov1.ClusterToken{}
+
+	err := r.Get(ctx, req.NamespacedName, clusterToken)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("clustertoken resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get clustertoken")
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Let's just set the status as Unknown when no status is available
+	if len(clusterToken.Status.Conditions) == 0 {
+		controller.UpdateStatus(ctx, r.Client, clusterToken, req.NamespacedName, func() {
+			meta.SetStatusCondition(&clusterToken.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionUnknown,
+				Type:               controller.ConditionTypeReconciling,
+				Reason:             "Reconciling",
+				Message:            "Starting reconciliation",
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return ctrl.Result{}, nil
+	}
+
+	secretKey := types.NamespacedName{
+		Namespace: controller.OperatorNamespace(),
+		Name:      clusterToken.Spec.Secret.SecretName,
+	}
+
+	nginxpmClient, err := issueToken(ctx, r, clusterToken.Spec.Endpoint, secretKey, clusterToken.Status, tokenRefreshRatio(r.RefreshRatio))
+	if err != nil {
+		controller.UpdateStatus(ctx, r.Client, clusterToken, req.NamespacedName, func() {
+			meta.SetStatusCondition(&clusterToken.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "InitNginxPMClient",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// The token secret lives next to the credentials, in the operator namespace
+	tokenSecretRef := &corev1.SecretReference{
+		Name:      clusterToken.Name + CLUSTER_TOKEN_JWT_SECRET_SUFFIX,
+		Namespace: controller.OperatorNamespace(),
+	}
+
+	changed, err := storeToken(ctx, r.Client, r.Scheme, clusterToken, tokenSecretRef, nginxpmClient)
+	if err != nil {
+		log.Error(err, "Failed to store the token in its secret")
+
+		controller.UpdateStatus(ctx, r.Client, clusterToken, req.NamespacedName, func() {
+			meta.SetStatusCondition(&clusterToken.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "StoreToken",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	if changed {
+		controller.ClientPool.Invalidate(clusterToken.UID)
+	}
+
+	if changed || clusterToken.Status.Token != nil || !equality.Semantic.DeepEqual(clusterToken.Status.TokenSecretRef, tokenSecretRef) {
+		if err := controller.UpdateStatus(ctx, r.Client, clusterToken, req.NamespacedName, func() {
+			clusterToken.Status.Token = nil
+			clusterToken.Status.TokenSecretRef = tokenSecretRef
+			clusterToken.Status.Expires = &metav1.Time{Time: nginxpmClient.Expires}
+			if changed || clusterToken.Status.IssuedAt == nil {
+				clusterToken.Status.IssuedAt = &metav1.Time{Time: time.Now()}
+			}
+		}); err != nil {
+			log.Error(err, "Failed to update ClusterToken status")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	requeueAfter := requeueAfterRefresh(clusterToken.Status.IssuedAt, nginxpmClient.Expires, tokenRefreshRatio(r.RefreshRatio))
+
+	controller.UpdateStatus(ctx, r.Client, clusterToken, req.NamespacedName, func() {
+		meta.SetStatusCondition(&clusterToken.Status.Conditions, metav1.Condition{
+			Status:             metav1.ConditionTrue,
+			Type:               controller.ConditionTypeReady,
+			Reason:             "TokenCreated",
+			Message:            fmt.Sprintf("Token created and expires at: %s", nginxpmClient.Expires.String()),
+			LastTransitionTime: metav1.Now(),
+		})
+	})
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterTokenReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &nginxpmoperatoriov1.ClusterToken{}, TOKEN_SECRET_FIELD, func(rawObj client.Object) []string {
+		clusterToken := rawObj.(*nginxpmoperatoriov1.ClusterToken)
+		if clusterToken.Spec.Secret.SecretName == "" {
+			return nil
+		}
+		return []string{clusterToken.Spec.Secret.SecretName}
+	}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&nginxpmoperatoriov1.ClusterToken{}).
+		Owns(&corev1.Secret{}).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
+		Named("clustertoken").
+		Complete(r)
+}
+
+func (r *ClusterTokenReconciler) findObjectsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	// Only the secrets of the operator namespace are used by cluster tokens
+	if secret.GetNamespace() != controller.OperatorNamespace() {
+		return []reconcile.Request{}
+	}
+
+	attachedSecrets := &nginxpmoperatoriov1.ClusterTokenList{}
+
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(TOKEN_SECRET_FIELD, secret.GetName()),
+	}
+
+	err := r.List(ctx, attachedSecrets, listOps)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(attachedSecrets.Items))
+	for i, item := range attachedSecrets.Items {
+		requests[i] = reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name: item.GetName(),
+			},
+		}
+	}
+
+	return requests
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +34,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logger "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -46,18 +48,29 @@ import (
 
 const (
 	TOKEN_SECRET_FIELD = ".spec.secret.secretName"
+
+	// Suffixes of the names of the secrets holding the issued tokens
+	TOKEN_JWT_SECRET_SUFFIX         = "-jwt"
+	CLUSTER_TOKEN_JWT_SECRET_SUFFIX = "-cluster-jwt"
+
+	// Fraction of the token lifetime after which it is refreshed
+	DEFAULT_TOKEN_REFRESH_RATIO = 0.8
 )
 
 // TokenReconciler reconciles a Token object
 type TokenReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// RefreshRatio is the fraction of the token lifetime after which it is refreshed,
+	// DEFAULT_TOKEN_REFRESH_RATIO when not set
+	RefreshRatio float64
 }
 
 // +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nginxpm-operator.io,resources=tokens/finalizers,verbs=update
-// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -117,13 +130,41 @@ func (r *TokenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{RequeueAfter: time.Minute}, err
 	}
 
-	// Update the status of the token with the new token and expiration time
-	if token.Status.Token == nil || *token.Status.Token != nginxpmClient.Token {
+	// Store the token in the secret owned by the token resource
+	tokenSecretRef := &corev1.SecretReference{Name: token.Name + TOKEN_JWT_SECRET_SUFFIX, Namespace: req.Namespace}
+
+	changed, err := storeToken(ctx, r.Client, r.Scheme, token, tokenSecretRef, nginxpmClient)
+	if err != nil {
+		log.Error(err, "Failed to store the token in its secret")
+
+		controller.UpdateStatus(ctx, r.Client, token, req.NamespacedName, func() {
+			meta.SetStatusCondition(&token.Status.Conditions, metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               controller.ConditionTypeError,
+				Reason:             "StoreToken",
+				Message:            err.Error(),
+				LastTransitionTime: metav1.Now(),
+			})
+		})
+
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Drop the pooled client still using the previous token
+	if changed {
+		controller.ClientPool.Invalidate(token.UID)
+	}
+
+	// Update the status of the token with the token secret and expiration time,
+	// clearing the token written in the status by previous versions
+	if changed || token.Status.Token != nil || !equality.Semantic.DeepEqual(token.Status.TokenSecretRef, tokenSecretRef) {
 		if err := controller.UpdateStatus(ctx, r.Client, token, req.NamespacedName, func() {
-			// Update the status of the token
-			// Set the token and expiration time in the status
-			token.Status.Token = &nginxpmClient.Token
+			token.Status.Token = nil
+			token.Status.TokenSecretRef = tokenSecretRef
 			token.Status.Expires = &metav1.Time{Time: nginxpmClient.Expires}
+			if changed || token.Status.IssuedAt == nil {
+				token.Status.IssuedAt = &metav1.Time{Time: time.Now()}
+			}
 		}); err != nil {
 			log.Error(err, "Failed to update Token status")
 			return ctrl.Result{}, nil
@@ -132,9 +173,8 @@ func (r *TokenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	fmt.Println("## Client Token created and expires at: ", nginxpmClient.Expires)
 
-	// Could be better to use the expiration time from the token status,
-	// but this is a quick fix
-	requeueAfter := nginxpmClient.Expires.UTC().Sub(metav1.Now().UTC())
+	// Requeue when the token has to be refreshed
+	requeueAfter := requeueAfterRefresh(token.Status.IssuedAt, nginxpmClient.Expires, tokenRefreshRatio(r.RefreshRatio))
 
 	// Set the status as True when the client can be created
 	controller.UpdateStatus(ctx, r.Client, token, req.NamespacedName, func() {
@@ -151,12 +191,20 @@ func (r *TokenReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 }
 
 func (r *TokenReconciler) initNginxPMClient(ctx context.Context, req reconcile.Request, token *nginxpmoperatoriov1.Token) (*nginxpm.Client, error) {
+	secretKey := types.NamespacedName{Namespace: req.Namespace, Name: token.Spec.Secret.SecretName}
+
+	return issueToken(ctx, r, token.Spec.Endpoint, secretKey, token.Status, tokenRefreshRatio(r.RefreshRatio))
+}
+
+// issueToken returns a client using the token issued in status while it is still valid, refreshing it
+// once refreshRatio of its lifetime has elapsed, or a client with a new token created from the
+// credentials of the secret otherwise.
+func issueToken(ctx context.Context, r client.Reader, endpoint string, secretKey types.NamespacedName, status nginxpmoperatoriov1.TokenStatus, refreshRatio float64) (*nginxpm.Client, error) {
 	log := logger.FromContext(ctx)
 
 	// Get the secret resource associated with the token
 	secret := &corev1.Secret{}
-	secretName := token.Spec.Secret.SecretName
-	if err := r.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: secretName}, secret); err != nil {
+	if err := r.Get(ctx, secretKey, secret); err != nil {
 		// If the secret resource is not found, we will not be able to create the token
 		log.Error(err, "Secret resource not found, please check the secret resource name")
 		return nil, err
@@ -181,20 +229,40 @@ func (r *TokenReconciler) initNginxPMClient(ctx context.Context, req reconcile.R
 	// Let create a new Nginx Proxy Manager client
 	var nginxpmClient *nginxpm.Client
 
+	// The token issued previously is read from its secret, or from the status
+	// when it was issued before the token was moved to a secret
+	var issuedToken string
+	if tokenSecret, err := controller.GetTokenSecret(ctx, r, status); err == nil {
+		issuedToken = string(tokenSecret.Data[nginxpm.TOKEN_SECRET_KEY])
+	} else if status.Token != nil {
+		issuedToken = *status.Token
+	}
+
 	// If the token is not empty, we will use it to create new client from
-	expiredAt := token.Status.Expires
-	hasValidToken := token.Status.Token != nil && expiredAt != nil && expiredAt.UTC().After(time.Now().UTC())
+	expiredAt := status.Expires
+	hasValidToken := issuedToken != "" && expiredAt != nil && expiredAt.UTC().After(time.Now().UTC())
 
 	// If the token is valid, we will use it to create new client from
 	if hasValidToken {
-		log.Info("Using token from status")
-		nginxpmClient = nginxpm.NewClientFromToken(util.NewHttpClient(), token)
+		log.Info("Using previously issued token")
+		nginxpmClient = nginxpm.NewClient(util.NewHttpClient(), endpoint)
+		nginxpmClient.Token = issuedToken
+		nginxpmClient.Expires = expiredAt.Time
 
 		// Check if the connection is established
-		if err := nginxpmClient.CheckConnection(); err != nil {
+		if err := nginxpmClient.CheckConnectionContext(ctx); err != nil {
 			log.Error(err, "Connect to the nginx-proxy-manager endpoint failed")
 			return nil, err
 		}
+
+		// Refresh the token before it expires, creating a new one only when it can't be refreshed
+		if !time.Now().Before(refreshTime(status.IssuedAt, expiredAt.Time, refreshRatio)) {
+			log.Info("Refreshing token")
+			if err := nginxpm.RefreshClientTokenContext(ctx, nginxpmClient); err != nil {
+				log.Error(err, "Failed to refresh token, creating a new one from identity and secret")
+				hasValidToken = false
+			}
+		}
 	}
 
 	// If the token is empty, we will use the identity
@@ -203,16 +271,16 @@ func (r *TokenReconciler) initNginxPMClient(ctx context.Context, req reconcile.R
 		log.Info("Instantiating new nginxpm client and create token")
 
 		// Let's create a new Nginx Proxy Manager client
-		nginxpmClient = nginxpm.NewClient(util.NewHttpClient(), token.Spec.Endpoint)
+		nginxpmClient = nginxpm.NewClient(util.NewHttpClient(), endpoint)
 
 		// Check if the connection is established
-		if err := nginxpmClient.CheckConnection(); err != nil {
+		if err := nginxpmClient.CheckConnectionContext(ctx); err != nil {
 			log.Error(err, "Connect to the nginx-proxy-manager endpoint failed")
 			return nil, err
 		}
 
 		// Let's create a new token from the identity and secret
-		if err := nginxpm.CreateClientToken(nginxpmClient, string(identity), string(secretDataValue)); err != nil {
+		if err := nginxpm.CreateClientTokenContext(ctx, nginxpmClient, string(identity), string(secretDataValue)); err != nil {
 			log.Error(err, "Failed to create token from identity and secret")
 			return nil, err
 		}
@@ -221,6 +289,68 @@ func (r *TokenReconciler) initNginxPMClient(ctx context.Context, req reconcile.R
 	return nginxpmClient, nil
 }
 
+// tokenRefreshRatio returns ratio when it is a valid fraction, DEFAULT_TOKEN_REFRESH_RATIO otherwise
+func tokenRefreshRatio(ratio float64) float64 {
+	if ratio <= 0 || ratio > 1 {
+		return DEFAULT_TOKEN_REFRESH_RATIO
+	}
+
+	return ratio
+}
+
+// refreshTime returns when a token issued at issuedAt and expiring at expires has to be refreshed.
+// Tokens without issue time, written by previous versions, are refreshed right away.
+func refreshTime(issuedAt *metav1.Time, expires time.Time, ratio float64) time.Time {
+	if issuedAt == nil {
+		return time.Time{}
+	}
+
+	lifetime := expires.Sub(issuedAt.Time)
+
+	return issuedAt.Add(time.Duration(float64(lifetime) * ratio))
+}
+
+// requeueAfterRefresh returns the delay until the token has to be refreshed
+func requeueAfterRefresh(issuedAt *metav1.Time, expires time.Time, ratio float64) time.Duration {
+	requeueAfter := time.Until(refreshTime(issuedAt, expires, ratio))
+	if requeueAfter < time.Second {
+		return time.Second
+	}
+
+	return requeueAfter
+}
+
+// storeToken writes the token of the client in the secret owned by the token resource,
+// it reports whether the secret was created or updated. It fails when the secret exists
+// and is not controlled by the token resource.
+func storeToken(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object, secretRef *corev1.SecretReference, nginxpmClient *nginxpm.Client) (bool, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretRef.Name,
+			Namespace: secretRef.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		// Taking over a secret written by someone else would get it deleted along with the token resource
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, owner) {
+			return fmt.Errorf("secret %s/%s already exists and is not controlled by %s", secret.Namespace, secret.Name, owner.GetName())
+		}
+
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{
+			nginxpm.TOKEN_SECRET_KEY: []byte(nginxpmClient.Token),
+		}
+
+		return controllerutil.SetControllerReference(owner, secret, scheme)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return result != controllerutil.OperationResultNone, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *TokenReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &nginxpmoperatoriov1.Token{}, TOKEN_SECRET_FIELD, func(rawObj client.Object) []string {
@@ -0,0 +1,61 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+func TestStoreToken(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = nginxpmoperatoriov1.AddToScheme(scheme)
+
+	token := &nginxpmoperatoriov1.Token{ObjectMeta: metav1.ObjectMeta{Name: "npm", Namespace: "default", UID: "token-uid"}}
+	existing := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other" + TOKEN_JWT_SECRET_SUFFIX, Namespace: "default"}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(token, existing).Build()
+	nginxpmClient := &nginxpm.Client{Token: "jwt"}
+
+	// A secret which is not controlled by the token is left alone
+	secretRef := &corev1.SecretReference{Name: existing.Name, Namespace: "default"}
+	if _, err := storeToken(context.Background(), c, scheme, token, secretRef, nginxpmClient); err == nil {
+		t.Error("Expected an error for a secret not controlled by the token")
+	}
+
+	secretRef = &corev1.SecretReference{Name: token.Name + TOKEN_JWT_SECRET_SUFFIX, Namespace: "default"}
+	changed, err := storeToken(context.Background(), c, scheme, token, secretRef, nginxpmClient)
+	if err != nil || !changed {
+		t.Fatalf("Expected the secret to be created, got changed %v, err %v", changed, err)
+	}
+
+	nginxpmClient.Token = "refreshed"
+	changed, err = storeToken(context.Background(), c, scheme, token, secretRef, nginxpmClient)
+	if err != nil || !changed {
+		t.Errorf("Expected the secret controlled by the token to be updated, got changed %v, err %v", changed, err)
+	}
+}
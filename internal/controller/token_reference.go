@@ -0,0 +1,55 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// PinTokenReference writes tokenRef, the reference of the Token used by the reconcile, in the spec.token
+// field of the object when it is not concrete yet, so the object no longer depends on the fallback lookup.
+// A token named in the spec is only pinned when it was found under that name in the namespace of the object.
+// The resources generated from an Ingress or a route are left alone, their spec is written by their owner.
+func PinTokenReference(ctx context.Context, c client.Client, obj client.Object, token **nginxpmoperatoriov1.TokenName, tokenRef *nginxpmoperatoriov1.TokenName) error {
+	if tokenRef == nil || IsClusterTokenRef(tokenRef) || IsClusterTokenRef(*token) || metav1.GetControllerOf(obj) != nil {
+		return nil
+	}
+
+	if *token != nil {
+		if (*token).Name == "" || (*token).Name != tokenRef.Name {
+			return nil
+		}
+
+		if (*token).Namespace != nil && *(*token).Namespace != "" {
+			return nil
+		}
+
+		if tokenRef.Namespace == nil || *tokenRef.Namespace != obj.GetNamespace() {
+			return nil
+		}
+	}
+
+	base := obj.DeepCopyObject().(client.Object)
+	*token = tokenRef
+
+	return c.Patch(ctx, obj, client.MergeFrom(base))
+}
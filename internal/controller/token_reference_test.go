@@ -0,0 +1,63 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+func TestPinTokenReference(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = nginxpmoperatoriov1.AddToScheme(scheme)
+
+	ph := &nginxpmoperatoriov1.ProxyHost{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ph).Build()
+
+	defaultNamespace := "default"
+	defaultRef := &nginxpmoperatoriov1.TokenName{Name: TOKEN_DEFAULT_NAME, Namespace: &defaultNamespace}
+
+	// The named token was not found, the default one used meanwhile must not replace it
+	ph.Spec.Token = &nginxpmoperatoriov1.TokenName{Name: "custom"}
+	if err := PinTokenReference(context.Background(), c, ph, &ph.Spec.Token, defaultRef); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ph.Spec.Token.Name != "custom" || ph.Spec.Token.Namespace != nil {
+		t.Errorf("Expected the named token to be left as is, got %+v", ph.Spec.Token)
+	}
+
+	// Without a token in the spec, the one found by the fallback lookup is pinned
+	ph.Spec.Token = nil
+	if err := PinTokenReference(context.Background(), c, ph, &ph.Spec.Token, defaultRef); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stored := &nginxpmoperatoriov1.ProxyHost{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "app", Namespace: "apps"}, stored); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stored.Spec.Token == nil || stored.Spec.Token.Name != TOKEN_DEFAULT_NAME || *stored.Spec.Token.Namespace != "default" {
+		t.Errorf("Expected the default token to be pinned, got %+v", stored.Spec.Token)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+	"github.com/paradoxe35/nginxpm-operator/pkg/util"
+)
+
+// COMMAND is the argument of the manager running the importer instead of the controllers
+const COMMAND = "import"
+
+// Run parses the arguments of the import command, imports the objects of the
+// Nginx Proxy Manager instance they designate and writes the resources to out
+func Run(ctx context.Context, args []string, out io.Writer) error {
+	var endpoint, identity, secret, namespace, tokenName, tokenNamespace, tokenKind string
+
+	flags := flag.NewFlagSet(COMMAND, flag.ContinueOnError)
+	flags.StringVar(&endpoint, "endpoint", "", "The base URL of the Nginx Proxy Manager instance, e.g. https://npm.example.com:81.")
+	flags.StringVar(&identity, "identity", os.Getenv("NGINXPM_IDENTITY"),
+		"The email used to log into Nginx Proxy Manager, defaults to the NGINXPM_IDENTITY environment variable.")
+	flags.StringVar(&secret, "secret", os.Getenv("NGINXPM_SECRET"),
+		"The password used to log into Nginx Proxy Manager, defaults to the NGINXPM_SECRET environment variable.")
+	flags.StringVar(&namespace, "namespace", "default", "The namespace of the generated resources.")
+	flags.StringVar(&tokenName, "token", "", "The Token referenced by the generated resources, the default token is used when empty.")
+	flags.StringVar(&tokenNamespace, "token-namespace", "", "The namespace of the Token referenced by the generated resources.")
+	flags.StringVar(&tokenKind, "token-kind", "", "The kind of the Token referenced by the generated resources, Token or ClusterToken.")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags]\n\n", COMMAND)
+		fmt.Fprintln(flags.Output(), "Writes the resources managing the proxy hosts, streams, access lists and Let's Encrypt certificates")
+		fmt.Fprintln(flags.Output(), "of an Nginx Proxy Manager instance. No status ids are generated, the resources are created with")
+		fmt.Fprintln(flags.Output(), "bindExisting and bind their objects again by domains, incoming port or name when applied.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if endpoint == "" || identity == "" || secret == "" {
+		return fmt.Errorf("the endpoint, identity and secret of the Nginx Proxy Manager instance are required")
+	}
+
+	opts := Options{Namespace: namespace}
+	if tokenName != "" {
+		opts.Token = &nginxpmoperatoriov1.TokenName{Name: tokenName, Kind: tokenKind}
+		if tokenNamespace != "" {
+			opts.Token.Namespace = &tokenNamespace
+		}
+	}
+
+	nginxpmClient := nginxpm.NewClient(util.NewHttpClient(), endpoint)
+	if err := nginxpm.CreateClientTokenContext(ctx, nginxpmClient, identity, secret); err != nil {
+		return fmt.Errorf("log into Nginx Proxy Manager: %w", err)
+	}
+
+	resources, err := Import(ctx, nginxpmClient, opts)
+	if err != nil {
+		return err
+	}
+
+	return WriteYAML(out, resources)
+}
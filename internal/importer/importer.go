@@ -0,0 +1,397 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// Characters which may not appear in the name of a resource
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Options configures the resources generated from the objects of an Nginx Proxy Manager instance
+type Options struct {
+	// Namespace of the generated resources
+	Namespace string
+
+	// Token referenced by the generated resources, the default token is looked up when nil
+	Token *nginxpmoperatoriov1.TokenName
+}
+
+// importer converts the objects of an instance into resources, it keeps track of the names given
+// to the resources so that the ones referencing each other use the same names
+type importer struct {
+	opts Options
+
+	// names given to the resources of each kind
+	names map[string]map[string]bool
+
+	// LetsEncryptCertificate names by NPM certificate id
+	certificates map[int]string
+
+	// AccessList names by NPM access list id
+	accessLists map[int]string
+}
+
+// Import reads the proxy hosts, streams, access lists and Let's Encrypt certificates of the instance
+// reached by nginxpmClient and returns the resources managing them. The ids of the objects are not
+// written in the status of the resources, which kubectl apply drops since status is a subresource.
+// The resources find their objects again by binding them instead: proxy hosts by their domains,
+// streams by their incoming port, access lists by their name and certificates by their domains.
+func Import(ctx context.Context, nginxpmClient *nginxpm.Client, opts Options) ([]*unstructured.Unstructured, error) {
+	im := &importer{
+		opts:         opts,
+		names:        map[string]map[string]bool{},
+		certificates: map[int]string{},
+		accessLists:  map[int]string{},
+	}
+
+	certificates, err := nginxpmClient.GetLetsEncryptCertificatesContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("import certificates: %w", err)
+	}
+
+	accessLists, err := nginxpmClient.GetAccessListsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("import access lists: %w", err)
+	}
+
+	proxyHosts, err := nginxpmClient.GetProxyHostsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("import proxy hosts: %w", err)
+	}
+
+	streams, err := nginxpmClient.GetStreamsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("import streams: %w", err)
+	}
+
+	var resources []*unstructured.Unstructured
+
+	for _, certificate := range certificates {
+		resource, err := im.letsEncryptCertificate(certificate)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+
+	for _, accessList := range accessLists {
+		resource, err := im.accessList(accessList)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+
+	for _, proxyHost := range proxyHosts {
+		resource, err := im.proxyHost(proxyHost)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+
+	for _, stream := range streams {
+		resource, err := im.stream(stream)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+// WriteYAML writes the resources as a multi-document YAML stream
+func WriteYAML(w io.Writer, resources []*unstructured.Unstructured) error {
+	for _, resource := range resources {
+		data, err := yaml.Marshal(resource.Object)
+		if err != nil {
+			return fmt.Errorf("marshal %s %s: %w", resource.GetKind(), resource.GetName(), err)
+		}
+
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ############################################# RESOURCES ##############################################
+
+func (im *importer) letsEncryptCertificate(certificate nginxpm.LetsEncryptCertificate) (*unstructured.Unstructured, error) {
+	lec := &nginxpmoperatoriov1.LetsEncryptCertificate{}
+	lec.Name = im.name("LetsEncryptCertificate", certificate.ID, firstDomain(certificate.DomainNames))
+	lec.Spec = nginxpmoperatoriov1.LetsEncryptCertificateSpec{
+		Token:            im.opts.Token,
+		DomainNames:      domainNames(certificate.DomainNames),
+		LetsEncryptEmail: certificate.Meta.LetsEncryptEmail,
+	}
+
+	// The credentials of the DNS provider are not returned by NPM, they must be stored in this secret
+	if certificate.Meta.DNSChallenge {
+		lec.Spec.DnsChallenge = &nginxpmoperatoriov1.DnsChallenge{
+			Provider: certificate.Meta.DNSProvider,
+			ProviderCredentials: nginxpmoperatoriov1.DnsChallengeProviderCredentials{
+				Secret: nginxpmoperatoriov1.DnsChallengeProviderCredentialsSecret{
+					Name: controller.GeneratedName(lec.Name, "dns-credentials"),
+				},
+			},
+		}
+	}
+
+	im.certificates[certificate.ID] = lec.Name
+
+	return im.toUnstructured(lec, "LetsEncryptCertificate", nil)
+}
+
+func (im *importer) accessList(accessList nginxpm.AccessList) (*unstructured.Unstructured, error) {
+	acl := &nginxpmoperatoriov1.AccessList{}
+	acl.Name = im.name("AccessList", accessList.ID, accessList.Name)
+	acl.Spec = nginxpmoperatoriov1.AccessListSpec{
+		Token:        im.opts.Token,
+		SatisfyAny:   accessList.SatisfyAny,
+		PassAuth:     accessList.PassAuth,
+		BindExisting: true,
+	}
+
+	// NPM does not return the passwords, an empty one keeps the password of the user
+	for _, item := range accessList.Items {
+		acl.Spec.Authorizations = append(acl.Spec.Authorizations, nginxpmoperatoriov1.AccessListAuthorization{
+			Username: item.Username,
+		})
+	}
+
+	for _, accessListClient := range accessList.Clients {
+		acl.Spec.Clients = append(acl.Spec.Clients, nginxpmoperatoriov1.AccessListClient{
+			Address:   accessListClient.Address,
+			Directive: accessListClient.Directive,
+		})
+	}
+
+	// The access list is bound by its name in NPM, which is kept when it is not a valid resource name
+	if acl.Name != accessList.Name {
+		acl.Spec.ListName = accessList.Name
+	}
+
+	im.accessLists[accessList.ID] = acl.Name
+
+	return im.toUnstructured(acl, "AccessList", nil)
+}
+
+func (im *importer) proxyHost(proxyHost nginxpm.ProxyHost) (*unstructured.Unstructured, error) {
+	ph := &nginxpmoperatoriov1.ProxyHost{}
+	ph.Name = im.name("ProxyHost", proxyHost.ID, firstDomain(proxyHost.DomainNames))
+	ph.Spec = nginxpmoperatoriov1.ProxyHostSpec{
+		Token:            im.opts.Token,
+		DomainNames:      domainNames(proxyHost.DomainNames),
+		Enabled:          &proxyHost.Enabled,
+		BindExisting:     true,
+		CachingEnabled:   proxyHost.CachingEnabled,
+		BlockExploits:    proxyHost.BlockExploits,
+		WebsocketSupport: proxyHost.AllowWebsocketUpgrade,
+		Forward: nginxpmoperatoriov1.ProxyHostForward{
+			Scheme:         proxyHost.ForwardScheme,
+			Hosts:          []nginxpmoperatoriov1.ForwardHost{forwardHost(proxyHost.ForwardHost, proxyHost.ForwardPort)},
+			AdvancedConfig: proxyHost.AdvancedConfig,
+		},
+	}
+
+	// The configuration written outside of the server block is only returned by the NPM fork supporting it
+	if proxyHost.UnscopedConfig != nil {
+		ph.Spec.UnscopedConfig = *proxyHost.UnscopedConfig
+	}
+
+	if proxyHost.AccessListID != 0 {
+		ph.Spec.AccessList = &nginxpmoperatoriov1.ProxyHostAccessList{Name: im.accessLists[proxyHost.AccessListID]}
+		if ph.Spec.AccessList.Name == "" {
+			accessListID := proxyHost.AccessListID
+			ph.Spec.AccessList.AccessListId = &accessListID
+		}
+	}
+
+	if proxyHost.CertificateID != 0 {
+		ph.Spec.Ssl = &nginxpmoperatoriov1.ProxyHostSsl{
+			SslForced:      proxyHost.SSLForced,
+			Http2Support:   proxyHost.HTTP2Support,
+			HstsEnabled:    proxyHost.HSTSEnabled,
+			HstsSubdomains: proxyHost.HSTSSubdomains,
+		}
+		ph.Spec.Ssl.LetsEncryptCertificate, ph.Spec.Ssl.CertificateId = im.certificate(proxyHost.CertificateID)
+	}
+
+	for _, location := range proxyHost.Locations {
+		// The path of the forward is sent appended to its host
+		host, path, _ := strings.Cut(location.ForwardHost, "/")
+		if path != "" {
+			path = "/" + path
+		}
+
+		ph.Spec.CustomLocations = append(ph.Spec.CustomLocations, nginxpmoperatoriov1.CustomLocation{
+			LocationPath: location.Path,
+			Forward: nginxpmoperatoriov1.ProxyHostForward{
+				Scheme:         location.ForwardScheme,
+				Hosts:          []nginxpmoperatoriov1.ForwardHost{forwardHost(host, location.ForwardPort)},
+				Path:           path,
+				AdvancedConfig: location.AdvancedConfig,
+			},
+		})
+	}
+
+	// These fields default to true, they must be written when they are not set
+	explicit := map[string]bool{
+		"spec.blockExploits":    proxyHost.BlockExploits,
+		"spec.websocketSupport": proxyHost.AllowWebsocketUpgrade,
+	}
+	if ph.Spec.Ssl != nil {
+		explicit["spec.ssl.sslForced"] = proxyHost.SSLForced
+		explicit["spec.ssl.http2Support"] = proxyHost.HTTP2Support
+	}
+
+	return im.toUnstructured(ph, "ProxyHost", explicit)
+}
+
+func (im *importer) stream(stream nginxpm.Stream) (*unstructured.Unstructured, error) {
+	st := &nginxpmoperatoriov1.Stream{}
+	st.Name = im.name("Stream", stream.ID, "stream", strconv.Itoa(stream.IncomingPort))
+	st.Spec = nginxpmoperatoriov1.StreamSpec{
+		Token:        im.opts.Token,
+		IncomingPort: stream.IncomingPort,
+		BindExisting: true,
+		Forward: nginxpmoperatoriov1.StreamForward{
+			TCPForwarding: stream.TCPForwarding,
+			UDPForwarding: stream.UDPForwarding,
+			Hosts:         []nginxpmoperatoriov1.ForwardHost{forwardHost(stream.ForwardingHost, stream.ForwardingPort)},
+		},
+	}
+
+	if stream.CertificateID != 0 {
+		st.Spec.Ssl = &nginxpmoperatoriov1.StreamSsl{}
+		st.Spec.Ssl.LetsEncryptCertificate, st.Spec.Ssl.CertificateId = im.certificate(stream.CertificateID)
+	}
+
+	// Both protocols are forwarded by default
+	explicit := map[string]bool{
+		"spec.forward.tcpForwarding": stream.TCPForwarding,
+		"spec.forward.udpForwarding": stream.UDPForwarding,
+	}
+
+	return im.toUnstructured(st, "Stream", explicit)
+}
+
+// ############################################# HELPERS ##############################################
+
+// certificate references the LetsEncryptCertificate imported for the certificate,
+// the certificate is referenced by its id when it was not imported
+func (im *importer) certificate(id int) (*nginxpmoperatoriov1.SslLetsEncryptCertificate, *int) {
+	if name, ok := im.certificates[id]; ok {
+		return &nginxpmoperatoriov1.SslLetsEncryptCertificate{Name: name}, nil
+	}
+
+	return nil, &id
+}
+
+// name returns a name for a resource of the kind, made from parts and unique among the resources
+// of the kind. The id of the object is appended to the name when it is already taken.
+func (im *importer) name(kind string, id int, parts ...string) string {
+	if im.names[kind] == nil {
+		im.names[kind] = map[string]bool{}
+	}
+
+	sanitized := make([]string, 0, len(parts))
+	for _, part := range parts {
+		part = strings.ReplaceAll(strings.ToLower(part), "*", "wildcard")
+		part = strings.Trim(invalidNameCharacters.ReplaceAllString(part, "-"), "-.")
+		if part != "" {
+			sanitized = append(sanitized, part)
+		}
+	}
+
+	name := controller.GeneratedName(sanitized...)
+	if name == "" {
+		name = controller.GeneratedName(strings.ToLower(kind), strconv.Itoa(id))
+	}
+
+	if im.names[kind][name] {
+		name = controller.GeneratedName(name, strconv.Itoa(id))
+	}
+	im.names[kind][name] = true
+
+	return name
+}
+
+// toUnstructured converts a resource to the object written in the YAML, with the boolean fields
+// given by explicit written even when they are false
+func (im *importer) toUnstructured(obj client.Object, kind string, explicit map[string]bool) (*unstructured.Unstructured, error) {
+	obj.SetNamespace(im.opts.Namespace)
+
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("convert %s %s: %w", kind, obj.GetName(), err)
+	}
+
+	resource := &unstructured.Unstructured{Object: data}
+	resource.SetAPIVersion(nginxpmoperatoriov1.GroupVersion.String())
+	resource.SetKind(kind)
+	unstructured.RemoveNestedField(resource.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(resource.Object, "status")
+
+	for path, value := range explicit {
+		if err := unstructured.SetNestedField(resource.Object, value, strings.Split(path, ".")...); err != nil {
+			return nil, fmt.Errorf("set %s of %s %s: %w", path, kind, obj.GetName(), err)
+		}
+	}
+
+	return resource, nil
+}
+
+func domainNames(domains []string) []nginxpmoperatoriov1.DomainName {
+	domainNames := make([]nginxpmoperatoriov1.DomainName, len(domains))
+	for i, domain := range domains {
+		domainNames[i] = nginxpmoperatoriov1.DomainName(domain)
+	}
+
+	return domainNames
+}
+
+func firstDomain(domains []string) string {
+	if len(domains) == 0 {
+		return ""
+	}
+
+	return domains[0]
+}
+
+func forwardHost(host string, port int) nginxpmoperatoriov1.ForwardHost {
+	return nginxpmoperatoriov1.ForwardHost{HostName: host, HostPort: int32(port)}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+func TestImport(t *testing.T) {
+	responses := map[string]string{
+		"/api/nginx/certificates": `[
+			{"id": 1, "provider": "letsencrypt", "domain_names": ["*.example.com"], "meta": {"letsencrypt_email": "admin@example.com"}},
+			{"id": 2, "provider": "other", "nice_name": "custom", "domain_names": ["custom.example.com"]}
+		]`,
+		"/api/nginx/access-lists": `[
+			{"id": 3, "name": "Internal Access", "satisfy_any": true, "items": [{"username": "admin"}], "clients": [{"address": "10.0.0.0/8", "directive": "allow"}]}
+		]`,
+		"/api/nginx/proxy-hosts": `[
+			{"id": 4, "domain_names": ["app.example.com"], "forward_scheme": "http", "forward_host": "10.0.0.1", "forward_port": 8080,
+			 "certificate_id": 1, "access_list_id": 3, "ssl_forced": true, "http2_support": false, "block_exploits": false,
+			 "unscoped_config": "limit_req_zone $binary_remote_addr zone=app:10m rate=10r/s;",
+			 "locations": [{"path": "/api", "forward_scheme": "http", "forward_host": "10.0.0.2/v1", "forward_port": 9090}]},
+			{"id": 5, "domain_names": ["App.example.com"], "forward_scheme": "https", "forward_host": "10.0.0.3", "forward_port": 443, "certificate_id": 2}
+		]`,
+		"/api/nginx/streams": `[
+			{"id": 6, "incoming_port": 5432, "forwarding_host": "10.0.0.4", "forwarding_port": 5432, "tcp_forwarding": true, "udp_forwarding": false}
+		]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	resources, err := Import(context.Background(), nginxpm.NewClient(server.Client(), server.URL), Options{Namespace: "npm"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	byName := map[string]*unstructured.Unstructured{}
+	for _, resource := range resources {
+		if resource.GetNamespace() != "npm" {
+			t.Errorf("Expected %s to be in the npm namespace, got %q", resource.GetName(), resource.GetNamespace())
+		}
+		byName[resource.GetKind()+"/"+resource.GetName()] = resource
+	}
+
+	if len(byName) != 5 {
+		t.Fatalf("Expected 5 resources, got %v", byName)
+	}
+
+	proxyHost := byName["ProxyHost/app.example.com"]
+	if proxyHost == nil {
+		t.Fatalf("Expected a ProxyHost named app.example.com, got %v", byName)
+	}
+
+	if name, _, _ := unstructured.NestedString(proxyHost.Object, "spec", "ssl", "letsEncryptCertificate", "name"); name != "wildcard.example.com" {
+		t.Errorf("Expected the imported certificate to be referenced, got %q", name)
+	}
+
+	if blockExploits, found, _ := unstructured.NestedBool(proxyHost.Object, "spec", "blockExploits"); !found || blockExploits {
+		t.Error("Expected blockExploits to be written as false")
+	}
+
+	if bindExisting, _, _ := unstructured.NestedBool(proxyHost.Object, "spec", "bindExisting"); !bindExisting {
+		t.Error("Expected the proxy host to be bound to the existing one")
+	}
+
+	if unscopedConfig, _, _ := unstructured.NestedString(proxyHost.Object, "spec", "unscopedConfig"); !strings.HasPrefix(unscopedConfig, "limit_req_zone") {
+		t.Errorf("Expected the unscoped config to be imported, got %q", unscopedConfig)
+	}
+
+	locations, _, _ := unstructured.NestedSlice(proxyHost.Object, "spec", "customLocations")
+	if len(locations) != 1 {
+		t.Fatalf("Expected 1 custom location, got %v", locations)
+	}
+	if path, _, _ := unstructured.NestedString(locations[0].(map[string]interface{}), "forward", "path"); path != "/v1" {
+		t.Errorf("Expected the forward path /v1, got %q", path)
+	}
+
+	// The name is already taken, the id is appended to it
+	duplicate := byName["ProxyHost/app.example.com-5"]
+	if duplicate == nil {
+		t.Fatalf("Expected a ProxyHost named app.example.com-5, got %v", byName)
+	}
+	if certificateId, _, _ := unstructured.NestedInt64(duplicate.Object, "spec", "ssl", "certificateId"); certificateId != 2 {
+		t.Errorf("Expected the certificate not imported to be referenced by id, got %d", certificateId)
+	}
+
+	if name, _, _ := unstructured.NestedString(proxyHost.Object, "spec", "accessList", "name"); name != "internal-access" {
+		t.Errorf("Expected the imported access list to be referenced, got %q", name)
+	}
+
+	accessList := byName["AccessList/internal-access"]
+	if accessList == nil {
+		t.Fatalf("Expected an AccessList named internal-access, got %v", byName)
+	}
+	if listName, _, _ := unstructured.NestedString(accessList.Object, "spec", "listName"); listName != "Internal Access" {
+		t.Errorf("Expected the access list to keep its name in NPM, got %q", listName)
+	}
+	if _, found := accessList.Object["status"]; found {
+		t.Error("Expected no status to be written, it is not applied")
+	}
+
+	var out bytes.Buffer
+	if err := WriteYAML(&out, resources); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stream := byName["Stream/stream-5432"]
+	if stream == nil {
+		t.Fatalf("Expected a Stream named stream-5432, got %v", byName)
+	}
+	if bindExisting, _, _ := unstructured.NestedBool(stream.Object, "spec", "bindExisting"); !bindExisting {
+		t.Error("Expected the stream to be bound to the existing one")
+	}
+
+	if !strings.Contains(out.String(), "udpForwarding: false") {
+		t.Errorf("Expected udpForwarding to be written as false, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var accesslistlog = logf.Log.WithName("accesslist-resource")
+
+// SetupAccessListWebhookWithManager registers the webhook for AccessList in the manager.
+func SetupAccessListWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.AccessList{}).
+		WithDefaulter(&AccessListCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&AccessListCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nginxpm-operator-io-v1-accesslist,mutating=true,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=accesslists,verbs=create,versions=v1,name=maccesslist-v1.kb.io,admissionReviewVersions=v1
+
+// AccessListCustomDefaulter resolves the Token of AccessList resources when they are created.
+type AccessListCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &AccessListCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type AccessList.
+func (d *AccessListCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	accesslist, ok := obj.(*nginxpmoperatoriov1.AccessList)
+	if !ok {
+		return fmt.Errorf("expected a AccessList object but got %T", obj)
+	}
+	accesslistlog.Info("Defaulting for AccessList", "name", accesslist.GetName())
+
+	return resolveTokenReference(ctx, d.Client, accesslist, &accesslist.Spec.Token)
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-accesslist,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=accesslists,verbs=create;update,versions=v1,name=vaccesslist-v1.kb.io,admissionReviewVersions=v1
+
+// AccessListCustomValidator validates AccessList resources when they are created or updated.
+type AccessListCustomValidator struct{}
+
+var _ webhook.CustomValidator = &AccessListCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type AccessList.
+func (v *AccessListCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	accesslist, ok := obj.(*nginxpmoperatoriov1.AccessList)
+	if !ok {
+		return nil, fmt.Errorf("expected a AccessList object but got %T", obj)
+	}
+	accesslistlog.Info("Validation for AccessList upon creation", "name", accesslist.GetName())
+
+	return accessListWarnings(accesslist), validateAccessList(accesslist)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type AccessList.
+func (v *AccessListCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	accesslist, ok := newObj.(*nginxpmoperatoriov1.AccessList)
+	if !ok {
+		return nil, fmt.Errorf("expected a AccessList object for the newObj but got %T", newObj)
+	}
+	accesslistlog.Info("Validation for AccessList upon update", "name", accesslist.GetName())
+
+	return accessListWarnings(accesslist), validateAccessList(accesslist)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type AccessList.
+func (v *AccessListCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateAccessList(al *nginxpmoperatoriov1.AccessList) error {
+	var errs field.ErrorList
+
+	authorizationsPath := field.NewPath("spec", "authorizations")
+
+	for i, authorization := range al.Spec.Authorizations {
+		if authorization.Password != "" && authorization.PasswordSecretRef != nil {
+			errs = append(errs, field.Forbidden(authorizationsPath.Index(i).Child("password"),
+				"may not be set together with passwordSecretRef"))
+		}
+	}
+
+	clientsPath := field.NewPath("spec", "clients")
+
+	for i, client := range al.Spec.Clients {
+		clientPath := clientsPath.Index(i)
+
+		if client.Directive != "allow" && client.Directive != "deny" {
+			errs = append(errs, field.NotSupported(clientPath.Child("directive"), client.Directive, []string{"allow", "deny"}))
+		}
+
+		switch {
+		case client.Source != nil && client.Address != "":
+			errs = append(errs, field.Forbidden(clientPath.Child("address"), "may not be set together with source"))
+		case client.Source != nil:
+			errs = append(errs, validateAccessListClientSource(clientPath.Child("source"), client.Source)...)
+		case !isIPOrCIDR(client.Address):
+			errs = append(errs, field.Invalid(clientPath.Child("address"), client.Address, "must be an IP address or a CIDR"))
+		}
+	}
+
+	errs = append(errs, validateDeletionPolicy(field.NewPath("spec", "deletionPolicy"), al.Spec.DeletionPolicy, al.Spec.BindExisting)...)
+
+	return invalid("AccessList", al.Name, errs)
+}
+
+// validateAccessListClientSource checks that exactly one source is set and that its node selector is valid
+func validateAccessListClientSource(path *field.Path, source *nginxpmoperatoriov1.AccessListClientSource) field.ErrorList {
+	var errs field.ErrorList
+
+	var set []string
+	if source.Nodes != nil {
+		set = append(set, "nodes")
+		errs = append(errs, validateNodeSelector(path.Child("nodes", "selector"), source.Nodes.Selector)...)
+	}
+	if source.PodCIDR != nil {
+		set = append(set, "podCIDR")
+		errs = append(errs, validateNodeSelector(path.Child("podCIDR", "selector"), source.PodCIDR.Selector)...)
+	}
+	if source.ConfigMapKeyRef != nil {
+		set = append(set, "configMapKeyRef")
+	}
+	if source.Service != nil {
+		set = append(set, "service")
+	}
+
+	if len(set) != 1 {
+		errs = append(errs, field.Invalid(path, set, "exactly one of nodes, podCIDR, configMapKeyRef and service must be set"))
+	}
+
+	return errs
+}
+
+func validateNodeSelector(path *field.Path, selector *metav1.LabelSelector) field.ErrorList {
+	if selector == nil {
+		return nil
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return field.ErrorList{field.Invalid(path, selector, err.Error())}
+	}
+
+	return nil
+}
+
+// accessListWarnings reports the passwords set in clear in the resource
+func accessListWarnings(al *nginxpmoperatoriov1.AccessList) admission.Warnings {
+	var warnings admission.Warnings
+
+	for i, authorization := range al.Spec.Authorizations {
+		if authorization.Password != "" {
+			warnings = append(warnings, fmt.Sprintf("spec.authorizations[%d].password is deprecated, use passwordSecretRef instead", i))
+		}
+	}
+
+	return warnings
+}
+
+func isIPOrCIDR(address string) bool {
+	if net.ParseIP(address) != nil {
+		return true
+	}
+
+	_, _, err := net.ParseCIDR(address)
+	return err == nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var clustertokenlog = logf.Log.WithName("clustertoken-resource")
+
+// SetupClusterTokenWebhookWithManager registers the webhook for ClusterToken in the manager.
+func SetupClusterTokenWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.ClusterToken{}).
+		WithValidator(&ClusterTokenCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-clustertoken,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=clustertokens,verbs=create;update,versions=v1,name=vclustertoken-v1.kb.io,admissionReviewVersions=v1
+
+// ClusterTokenCustomValidator validates ClusterToken resources when they are created or updated.
+type ClusterTokenCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ClusterTokenCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterToken.
+func (v *ClusterTokenCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterToken, ok := obj.(*nginxpmoperatoriov1.ClusterToken)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterToken object but got %T", obj)
+	}
+	clustertokenlog.Info("Validation for ClusterToken upon creation", "name", clusterToken.GetName())
+
+	return clusterTokenWarnings(clusterToken), validateClusterToken(clusterToken)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterToken.
+func (v *ClusterTokenCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	clusterToken, ok := newObj.(*nginxpmoperatoriov1.ClusterToken)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterToken object for the newObj but got %T", newObj)
+	}
+	clustertokenlog.Info("Validation for ClusterToken upon update", "name", clusterToken.GetName())
+
+	return clusterTokenWarnings(clusterToken), validateClusterToken(clusterToken)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterToken.
+func (v *ClusterTokenCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateClusterToken(clusterToken *nginxpmoperatoriov1.ClusterToken) error {
+	specPath := field.NewPath("spec")
+
+	errs := validateTokenSpec(specPath, &clusterToken.Spec.TokenSpec)
+
+	if clusterToken.Spec.AllowedNamespaces != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(clusterToken.Spec.AllowedNamespaces,
+			metav1validation.LabelSelectorValidationOptions{}, specPath.Child("allowedNamespaces"))...)
+	}
+
+	return invalid("ClusterToken", clusterToken.Name, errs)
+}
+
+// clusterTokenWarnings warns about a ClusterToken which no namespace is allowed to use
+func clusterTokenWarnings(clusterToken *nginxpmoperatoriov1.ClusterToken) admission.Warnings {
+	if clusterToken.Spec.AllowedNamespaces == nil {
+		return admission.Warnings{"spec.allowedNamespaces is not set, no namespace is allowed to use this ClusterToken"}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var customcertificatelog = logf.Log.WithName("customcertificate-resource")
+
+// SetupCustomCertificateWebhookWithManager registers the webhook for CustomCertificate in the manager.
+func SetupCustomCertificateWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.CustomCertificate{}).
+		WithDefaulter(&CustomCertificateCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&CustomCertificateCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nginxpm-operator-io-v1-customcertificate,mutating=true,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=customcertificates,verbs=create,versions=v1,name=mcustomcertificate-v1.kb.io,admissionReviewVersions=v1
+
+// CustomCertificateCustomDefaulter resolves the Token of CustomCertificate resources when they are created.
+type CustomCertificateCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &CustomCertificateCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type CustomCertificate.
+func (d *CustomCertificateCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	customcertificate, ok := obj.(*nginxpmoperatoriov1.CustomCertificate)
+	if !ok {
+		return fmt.Errorf("expected a CustomCertificate object but got %T", obj)
+	}
+	customcertificatelog.Info("Defaulting for CustomCertificate", "name", customcertificate.GetName())
+
+	return resolveTokenReference(ctx, d.Client, customcertificate, &customcertificate.Spec.Token)
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-customcertificate,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=customcertificates,verbs=create;update,versions=v1,name=vcustomcertificate-v1.kb.io,admissionReviewVersions=v1
+
+// CustomCertificateCustomValidator validates CustomCertificate resources when they are created or updated.
+type CustomCertificateCustomValidator struct{}
+
+var _ webhook.CustomValidator = &CustomCertificateCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type CustomCertificate.
+func (v *CustomCertificateCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	customcertificate, ok := obj.(*nginxpmoperatoriov1.CustomCertificate)
+	if !ok {
+		return nil, fmt.Errorf("expected a CustomCertificate object but got %T", obj)
+	}
+	customcertificatelog.Info("Validation for CustomCertificate upon creation", "name", customcertificate.GetName())
+
+	return nil, validateCustomCertificate(customcertificate)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type CustomCertificate.
+func (v *CustomCertificateCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	customcertificate, ok := newObj.(*nginxpmoperatoriov1.CustomCertificate)
+	if !ok {
+		return nil, fmt.Errorf("expected a CustomCertificate object for the newObj but got %T", newObj)
+	}
+	customcertificatelog.Info("Validation for CustomCertificate upon update", "name", customcertificate.GetName())
+
+	return nil, validateCustomCertificate(customcertificate)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type CustomCertificate.
+func (v *CustomCertificateCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateCustomCertificate(cc *nginxpmoperatoriov1.CustomCertificate) error {
+	var errs field.ErrorList
+
+	if cc.Spec.Certificate.Secret.Name == "" {
+		errs = append(errs, field.Required(field.NewPath("spec", "certificate", "secret", "name"), ""))
+	}
+
+	return invalid("CustomCertificate", cc.Name, errs)
+}
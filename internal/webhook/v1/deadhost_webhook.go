@@ -0,0 +1,111 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var deadhostlog = logf.Log.WithName("deadhost-resource")
+
+// SetupDeadHostWebhookWithManager registers the webhook for DeadHost in the manager.
+func SetupDeadHostWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.DeadHost{}).
+		WithDefaulter(&DeadHostCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&DeadHostCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nginxpm-operator-io-v1-deadhost,mutating=true,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=deadhosts,verbs=create,versions=v1,name=mdeadhost-v1.kb.io,admissionReviewVersions=v1
+
+// DeadHostCustomDefaulter resolves the Token of DeadHost resources when they are created.
+type DeadHostCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &DeadHostCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type DeadHost.
+func (d *DeadHostCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	deadhost, ok := obj.(*nginxpmoperatoriov1.DeadHost)
+	if !ok {
+		return fmt.Errorf("expected a DeadHost object but got %T", obj)
+	}
+	deadhostlog.Info("Defaulting for DeadHost", "name", deadhost.GetName())
+
+	return resolveTokenReference(ctx, d.Client, deadhost, &deadhost.Spec.Token)
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-deadhost,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=deadhosts,verbs=create;update,versions=v1,name=vdeadhost-v1.kb.io,admissionReviewVersions=v1
+
+// DeadHostCustomValidator validates DeadHost resources when they are created or updated.
+type DeadHostCustomValidator struct{}
+
+var _ webhook.CustomValidator = &DeadHostCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DeadHost.
+func (v *DeadHostCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	deadhost, ok := obj.(*nginxpmoperatoriov1.DeadHost)
+	if !ok {
+		return nil, fmt.Errorf("expected a DeadHost object but got %T", obj)
+	}
+	deadhostlog.Info("Validation for DeadHost upon creation", "name", deadhost.GetName())
+
+	return nil, validateDeadHost(deadhost)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DeadHost.
+func (v *DeadHostCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	deadhost, ok := newObj.(*nginxpmoperatoriov1.DeadHost)
+	if !ok {
+		return nil, fmt.Errorf("expected a DeadHost object for the newObj but got %T", newObj)
+	}
+	deadhostlog.Info("Validation for DeadHost upon update", "name", deadhost.GetName())
+
+	return nil, validateDeadHost(deadhost)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DeadHost.
+func (v *DeadHostCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateDeadHost(dh *nginxpmoperatoriov1.DeadHost) error {
+	var errs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	errs = append(errs, validateDomainNames(specPath.Child("domainNames"), dh.Spec.DomainNames)...)
+
+	if dh.Spec.Ssl != nil {
+		errs = append(errs, validateCertificate(specPath.Child("ssl"), dh.Spec.Ssl.CertificateId, dh.Spec.Ssl.LetsEncryptCertificate)...)
+	}
+
+	return invalid("DeadHost", dh.Name, errs)
+}
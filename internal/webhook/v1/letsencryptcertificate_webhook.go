@@ -0,0 +1,115 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var letsencryptcertificatelog = logf.Log.WithName("letsencryptcertificate-resource")
+
+// SetupLetsEncryptCertificateWebhookWithManager registers the webhook for LetsEncryptCertificate in the manager.
+func SetupLetsEncryptCertificateWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.LetsEncryptCertificate{}).
+		WithDefaulter(&LetsEncryptCertificateCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&LetsEncryptCertificateCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nginxpm-operator-io-v1-letsencryptcertificate,mutating=true,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=letsencryptcertificates,verbs=create,versions=v1,name=mletsencryptcertificate-v1.kb.io,admissionReviewVersions=v1
+
+// LetsEncryptCertificateCustomDefaulter resolves the Token of LetsEncryptCertificate resources when they are created.
+type LetsEncryptCertificateCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &LetsEncryptCertificateCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type LetsEncryptCertificate.
+func (d *LetsEncryptCertificateCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	letsencryptcertificate, ok := obj.(*nginxpmoperatoriov1.LetsEncryptCertificate)
+	if !ok {
+		return fmt.Errorf("expected a LetsEncryptCertificate object but got %T", obj)
+	}
+	letsencryptcertificatelog.Info("Defaulting for LetsEncryptCertificate", "name", letsencryptcertificate.GetName())
+
+	return resolveTokenReference(ctx, d.Client, letsencryptcertificate, &letsencryptcertificate.Spec.Token)
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-letsencryptcertificate,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=letsencryptcertificates,verbs=create;update,versions=v1,name=vletsencryptcertificate-v1.kb.io,admissionReviewVersions=v1
+
+// LetsEncryptCertificateCustomValidator validates LetsEncryptCertificate resources when they are created or updated.
+type LetsEncryptCertificateCustomValidator struct{}
+
+var _ webhook.CustomValidator = &LetsEncryptCertificateCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type LetsEncryptCertificate.
+func (v *LetsEncryptCertificateCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	letsencryptcertificate, ok := obj.(*nginxpmoperatoriov1.LetsEncryptCertificate)
+	if !ok {
+		return nil, fmt.Errorf("expected a LetsEncryptCertificate object but got %T", obj)
+	}
+	letsencryptcertificatelog.Info("Validation for LetsEncryptCertificate upon creation", "name", letsencryptcertificate.GetName())
+
+	return nil, validateLetsEncryptCertificate(letsencryptcertificate)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type LetsEncryptCertificate.
+func (v *LetsEncryptCertificateCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	letsencryptcertificate, ok := newObj.(*nginxpmoperatoriov1.LetsEncryptCertificate)
+	if !ok {
+		return nil, fmt.Errorf("expected a LetsEncryptCertificate object for the newObj but got %T", newObj)
+	}
+	letsencryptcertificatelog.Info("Validation for LetsEncryptCertificate upon update", "name", letsencryptcertificate.GetName())
+
+	return nil, validateLetsEncryptCertificate(letsencryptcertificate)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type LetsEncryptCertificate.
+func (v *LetsEncryptCertificateCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateLetsEncryptCertificate(lec *nginxpmoperatoriov1.LetsEncryptCertificate) error {
+	var errs field.ErrorList
+
+	domainsPath := field.NewPath("spec", "domainNames")
+
+	if len(lec.Spec.DomainNames) == 0 {
+		errs = append(errs, field.Required(domainsPath, "at least one domain is required"))
+	}
+
+	errs = append(errs, validateDomainNames(domainsPath, lec.Spec.DomainNames)...)
+
+	if lec.Spec.RenewBefore != nil && lec.Spec.RenewBefore.Duration <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "renewBefore"), lec.Spec.RenewBefore.Duration.String(), "must be positive"))
+	}
+
+	return invalid("LetsEncryptCertificate", lec.Name, errs)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var proxyhostlog = logf.Log.WithName("proxyhost-resource")
+
+// SetupProxyHostWebhookWithManager registers the webhook for ProxyHost in the manager.
+func SetupProxyHostWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.ProxyHost{}).
+		WithDefaulter(&ProxyHostCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&ProxyHostCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nginxpm-operator-io-v1-proxyhost,mutating=true,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=proxyhosts,verbs=create,versions=v1,name=mproxyhost-v1.kb.io,admissionReviewVersions=v1
+
+// ProxyHostCustomDefaulter resolves the Token of ProxyHost resources when they are created.
+type ProxyHostCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &ProxyHostCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type ProxyHost.
+func (d *ProxyHostCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	proxyhost, ok := obj.(*nginxpmoperatoriov1.ProxyHost)
+	if !ok {
+		return fmt.Errorf("expected a ProxyHost object but got %T", obj)
+	}
+	proxyhostlog.Info("Defaulting for ProxyHost", "name", proxyhost.GetName())
+
+	return resolveTokenReference(ctx, d.Client, proxyhost, &proxyhost.Spec.Token)
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-proxyhost,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=proxyhosts,verbs=create;update,versions=v1,name=vproxyhost-v1.kb.io,admissionReviewVersions=v1
+
+// ProxyHostCustomValidator validates ProxyHost resources when they are created or updated.
+type ProxyHostCustomValidator struct {
+	// Client is used to check that domains are not used by another ProxyHost
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ProxyHostCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ProxyHost.
+func (v *ProxyHostCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	proxyhost, ok := obj.(*nginxpmoperatoriov1.ProxyHost)
+	if !ok {
+		return nil, fmt.Errorf("expected a ProxyHost object but got %T", obj)
+	}
+	proxyhostlog.Info("Validation for ProxyHost upon creation", "name", proxyhost.GetName())
+
+	return nil, v.validate(ctx, proxyhost)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ProxyHost.
+func (v *ProxyHostCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	proxyhost, ok := newObj.(*nginxpmoperatoriov1.ProxyHost)
+	if !ok {
+		return nil, fmt.Errorf("expected a ProxyHost object for the newObj but got %T", newObj)
+	}
+	proxyhostlog.Info("Validation for ProxyHost upon update", "name", proxyhost.GetName())
+
+	return nil, v.validate(ctx, proxyhost)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ProxyHost.
+func (v *ProxyHostCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ProxyHostCustomValidator) validate(ctx context.Context, ph *nginxpmoperatoriov1.ProxyHost) error {
+	var errs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	errs = append(errs, validateDomainNames(specPath.Child("domainNames"), ph.Spec.DomainNames)...)
+	errs = append(errs, validateForward(specPath.Child("forward"), ph.Spec.Forward.Hosts, ph.Spec.Forward.Service)...)
+
+	for i, location := range ph.Spec.CustomLocations {
+		forwardPath := specPath.Child("customLocations").Index(i).Child("forward")
+		errs = append(errs, validateForward(forwardPath, location.Forward.Hosts, location.Forward.Service)...)
+	}
+
+	if ph.Spec.Ssl != nil {
+		errs = append(errs, validateCertificate(specPath.Child("ssl"), ph.Spec.Ssl.CertificateId, ph.Spec.Ssl.LetsEncryptCertificate)...)
+	}
+
+	if ph.Spec.AccessList != nil && ph.Spec.AccessRules != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("accessRules"),
+			fmt.Sprintf("may not be set together with %s", specPath.Child("accessList"))))
+	}
+
+	errs = append(errs, validateDeletionPolicy(specPath.Child("deletionPolicy"), ph.Spec.DeletionPolicy, ph.Spec.BindExisting)...)
+
+	domainErrs, err := v.validateDomainsUnique(ctx, specPath.Child("domainNames"), ph)
+	if err != nil {
+		return err
+	}
+	errs = append(errs, domainErrs...)
+
+	return invalid("ProxyHost", ph.Name, errs)
+}
+
+// validateDomainsUnique rejects domains already used by another ProxyHost
+func (v *ProxyHostCustomValidator) validateDomainsUnique(ctx context.Context, path *field.Path, ph *nginxpmoperatoriov1.ProxyHost) (field.ErrorList, error) {
+	var errs field.ErrorList
+
+	if v.Client == nil {
+		return errs, nil
+	}
+
+	proxyHosts := &nginxpmoperatoriov1.ProxyHostList{}
+	if err := v.Client.List(ctx, proxyHosts); err != nil {
+		return nil, fmt.Errorf("list proxy hosts: %w", err)
+	}
+
+	used := map[nginxpmoperatoriov1.DomainName]*nginxpmoperatoriov1.ProxyHost{}
+	for i, proxyHost := range proxyHosts.Items {
+		if proxyHost.Name == ph.Name && proxyHost.Namespace == ph.Namespace {
+			continue
+		}
+
+		for _, domain := range proxyHost.Spec.DomainNames {
+			used[domain] = &proxyHosts.Items[i]
+		}
+	}
+
+	for i, domain := range ph.Spec.DomainNames {
+		if proxyHost, ok := used[domain]; ok {
+			errs = append(errs, field.Invalid(path.Index(i), domain,
+				fmt.Sprintf("domain is already used by another proxy host: (name: %s, namespace: %s)",
+					proxyHost.Name, proxyHost.Namespace)))
+		}
+	}
+
+	return errs, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var redirectionhostlog = logf.Log.WithName("redirectionhost-resource")
+
+// SetupRedirectionHostWebhookWithManager registers the webhook for RedirectionHost in the manager.
+func SetupRedirectionHostWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.RedirectionHost{}).
+		WithDefaulter(&RedirectionHostCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&RedirectionHostCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nginxpm-operator-io-v1-redirectionhost,mutating=true,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=redirectionhosts,verbs=create,versions=v1,name=mredirectionhost-v1.kb.io,admissionReviewVersions=v1
+
+// RedirectionHostCustomDefaulter resolves the Token of RedirectionHost resources when they are created.
+type RedirectionHostCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &RedirectionHostCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type RedirectionHost.
+func (d *RedirectionHostCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	redirectionhost, ok := obj.(*nginxpmoperatoriov1.RedirectionHost)
+	if !ok {
+		return fmt.Errorf("expected a RedirectionHost object but got %T", obj)
+	}
+	redirectionhostlog.Info("Defaulting for RedirectionHost", "name", redirectionhost.GetName())
+
+	return resolveTokenReference(ctx, d.Client, redirectionhost, &redirectionhost.Spec.Token)
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-redirectionhost,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=redirectionhosts,verbs=create;update,versions=v1,name=vredirectionhost-v1.kb.io,admissionReviewVersions=v1
+
+// RedirectionHostCustomValidator validates RedirectionHost resources when they are created or updated.
+type RedirectionHostCustomValidator struct{}
+
+var _ webhook.CustomValidator = &RedirectionHostCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RedirectionHost.
+func (v *RedirectionHostCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	redirectionhost, ok := obj.(*nginxpmoperatoriov1.RedirectionHost)
+	if !ok {
+		return nil, fmt.Errorf("expected a RedirectionHost object but got %T", obj)
+	}
+	redirectionhostlog.Info("Validation for RedirectionHost upon creation", "name", redirectionhost.GetName())
+
+	return nil, validateRedirectionHost(redirectionhost)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RedirectionHost.
+func (v *RedirectionHostCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	redirectionhost, ok := newObj.(*nginxpmoperatoriov1.RedirectionHost)
+	if !ok {
+		return nil, fmt.Errorf("expected a RedirectionHost object for the newObj but got %T", newObj)
+	}
+	redirectionhostlog.Info("Validation for RedirectionHost upon update", "name", redirectionhost.GetName())
+
+	return nil, validateRedirectionHost(redirectionhost)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RedirectionHost.
+func (v *RedirectionHostCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateRedirectionHost(rh *nginxpmoperatoriov1.RedirectionHost) error {
+	var errs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	errs = append(errs, validateDomainNames(specPath.Child("domainNames"), rh.Spec.DomainNames)...)
+
+	if rh.Spec.Forward.DomainName == "" {
+		errs = append(errs, field.Required(specPath.Child("forward", "domainName"), ""))
+	}
+
+	if rh.Spec.Ssl != nil {
+		errs = append(errs, validateCertificate(specPath.Child("ssl"), rh.Spec.Ssl.CertificateId, rh.Spec.Ssl.LetsEncryptCertificate)...)
+	}
+
+	errs = append(errs, validateDeletionPolicy(specPath.Child("deletionPolicy"), rh.Spec.DeletionPolicy, rh.Spec.BindExisting)...)
+
+	return invalid("RedirectionHost", rh.Name, errs)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var streamlog = logf.Log.WithName("stream-resource")
+
+// SetupStreamWebhookWithManager registers the webhook for Stream in the manager.
+func SetupStreamWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.Stream{}).
+		WithDefaulter(&StreamCustomDefaulter{Client: mgr.GetClient()}).
+		WithValidator(&StreamCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-nginxpm-operator-io-v1-stream,mutating=true,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=streams,verbs=create,versions=v1,name=mstream-v1.kb.io,admissionReviewVersions=v1
+
+// StreamCustomDefaulter resolves the Token of Stream resources when they are created.
+type StreamCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &StreamCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type Stream.
+func (d *StreamCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	stream, ok := obj.(*nginxpmoperatoriov1.Stream)
+	if !ok {
+		return fmt.Errorf("expected a Stream object but got %T", obj)
+	}
+	streamlog.Info("Defaulting for Stream", "name", stream.GetName())
+
+	return resolveTokenReference(ctx, d.Client, stream, &stream.Spec.Token)
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-stream,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=streams,verbs=create;update,versions=v1,name=vstream-v1.kb.io,admissionReviewVersions=v1
+
+// StreamCustomValidator validates Stream resources when they are created or updated.
+type StreamCustomValidator struct{}
+
+var _ webhook.CustomValidator = &StreamCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Stream.
+func (v *StreamCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	stream, ok := obj.(*nginxpmoperatoriov1.Stream)
+	if !ok {
+		return nil, fmt.Errorf("expected a Stream object but got %T", obj)
+	}
+	streamlog.Info("Validation for Stream upon creation", "name", stream.GetName())
+
+	return nil, validateStream(stream)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Stream.
+func (v *StreamCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	stream, ok := newObj.(*nginxpmoperatoriov1.Stream)
+	if !ok {
+		return nil, fmt.Errorf("expected a Stream object for the newObj but got %T", newObj)
+	}
+	streamlog.Info("Validation for Stream upon update", "name", stream.GetName())
+
+	return nil, validateStream(stream)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Stream.
+func (v *StreamCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateStream(st *nginxpmoperatoriov1.Stream) error {
+	var errs field.ErrorList
+
+	specPath := field.NewPath("spec")
+
+	if err := validatePort(specPath.Child("incomingPort"), st.Spec.IncomingPort); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, validateForward(specPath.Child("forward"), st.Spec.Forward.Hosts, st.Spec.Forward.Service)...)
+
+	if st.Spec.Ssl != nil {
+		errs = append(errs, validateCertificate(specPath.Child("ssl"), st.Spec.Ssl.CertificateId, st.Spec.Ssl.LetsEncryptCertificate)...)
+	}
+
+	errs = append(errs, validateDeletionPolicy(specPath.Child("deletionPolicy"), st.Spec.DeletionPolicy, st.Spec.BindExisting)...)
+
+	return invalid("Stream", st.Name, errs)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+)
+
+// resolveTokenReference writes the concrete name and namespace of the Token used by the object in
+// its spec.token field, so it no longer depends on the fallback lookup done at every reconcile.
+// A token named in the spec is left as is unless it exists under that name.
+func resolveTokenReference(ctx context.Context, c client.Reader, obj client.Object, token **nginxpmoperatoriov1.TokenName) error {
+	// A ClusterToken reference is already concrete, it has no fallback lookup
+	if c == nil || controller.IsClusterTokenRef(*token) {
+		return nil
+	}
+
+	// The namespace is not always set on the object at creation time
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		if req, err := admission.RequestFromContext(ctx); err == nil {
+			namespace = req.Namespace
+		}
+	}
+
+	// A token named in the spec is only pinned when it exists, the fallback lookup would otherwise
+	// replace it for good with another token while it is not created yet
+	if *token != nil {
+		if (*token).Name == "" {
+			return nil
+		}
+
+		if (*token).Namespace != nil && *(*token).Namespace != "" {
+			namespace = *(*token).Namespace
+		}
+
+		named := &nginxpmoperatoriov1.Token{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: (*token).Name}, named); err != nil {
+			return nil
+		}
+
+		*token = controller.TokenRef(named)
+
+		return nil
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: obj.GetName()}}
+
+	resolved, err := controller.FindToken(ctx, c, req, *token)
+	if err != nil {
+		// The token may be created later, the controller keeps looking for it until then
+		return nil
+	}
+
+	*token = controller.TokenRef(resolved)
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+func TestResolveTokenReference(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = nginxpmoperatoriov1.AddToScheme(scheme)
+
+	defaultToken := &nginxpmoperatoriov1.Token{ObjectMeta: metav1.ObjectMeta{Name: "token-nginxpm", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(defaultToken).Build()
+
+	ph := &nginxpmoperatoriov1.ProxyHost{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps"}}
+
+	// The named token does not exist yet, the default one must not replace it
+	token := &nginxpmoperatoriov1.TokenName{Name: "custom"}
+	if err := resolveTokenReference(context.Background(), c, ph, &token); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token.Name != "custom" || token.Namespace != nil {
+		t.Errorf("Expected the named token to be left as is, got %+v", token)
+	}
+
+	if err := c.Create(context.Background(), &nginxpmoperatoriov1.Token{ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "apps"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := resolveTokenReference(context.Background(), c, ph, &token); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token.Name != "custom" || token.Namespace == nil || *token.Namespace != "apps" {
+		t.Errorf("Expected the named token to be pinned in the apps namespace, got %+v", token)
+	}
+
+	// Without a token in the spec, the one found by the fallback lookup is pinned
+	token = nil
+	if err := resolveTokenReference(context.Background(), c, ph, &token); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token == nil || token.Name != "token-nginxpm" || *token.Namespace != "default" {
+		t.Errorf("Expected the default token to be pinned, got %+v", token)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+// log is for logging in this package.
+var tokenlog = logf.Log.WithName("token-resource")
+
+// SetupTokenWebhookWithManager registers the webhook for Token in the manager.
+func SetupTokenWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&nginxpmoperatoriov1.Token{}).
+		WithValidator(&TokenCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-nginxpm-operator-io-v1-token,mutating=false,failurePolicy=fail,sideEffects=None,groups=nginxpm-operator.io,resources=tokens,verbs=create;update,versions=v1,name=vtoken-v1.kb.io,admissionReviewVersions=v1
+
+// TokenCustomValidator validates Token resources when they are created or updated.
+type TokenCustomValidator struct{}
+
+var _ webhook.CustomValidator = &TokenCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Token.
+func (v *TokenCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	token, ok := obj.(*nginxpmoperatoriov1.Token)
+	if !ok {
+		return nil, fmt.Errorf("expected a Token object but got %T", obj)
+	}
+	tokenlog.Info("Validation for Token upon creation", "name", token.GetName())
+
+	return nil, validateToken(token)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Token.
+func (v *TokenCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	token, ok := newObj.(*nginxpmoperatoriov1.Token)
+	if !ok {
+		return nil, fmt.Errorf("expected a Token object for the newObj but got %T", newObj)
+	}
+	tokenlog.Info("Validation for Token upon update", "name", token.GetName())
+
+	return nil, validateToken(token)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Token.
+func (v *TokenCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateToken(token *nginxpmoperatoriov1.Token) error {
+	return invalid("Token", token.Name, validateTokenSpec(field.NewPath("spec"), &token.Spec))
+}
+
+// validateTokenSpec checks the endpoint and secret shared by Token and ClusterToken resources
+func validateTokenSpec(specPath *field.Path, spec *nginxpmoperatoriov1.TokenSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	endpoint, err := url.Parse(spec.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		errs = append(errs, field.Invalid(specPath.Child("endpoint"), spec.Endpoint, "must be an http or https URL"))
+	}
+
+	if spec.Secret.SecretName == "" {
+		errs = append(errs, field.Required(specPath.Child("secret", "secretName"), ""))
+	}
+
+	return errs
+}
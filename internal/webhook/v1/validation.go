@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/internal/controller"
+)
+
+// Same pattern as the DomainName type validation, NPM does not accept a port in the domains of a host
+var domainNamePattern = regexp.MustCompile(`^(\*\.)?[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)*\.[a-zA-Z]{2,}$`)
+
+// invalid wraps the field errors into the error returned to the API server, nil when there is none
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(nginxpmoperatoriov1.GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
+
+func validatePort(path *field.Path, port int) *field.Error {
+	if port < 1 || port > 65535 {
+		return field.Invalid(path, port, "must be between 1 and 65535")
+	}
+
+	return nil
+}
+
+// validateDomainNames checks the format of each domain and that no domain is repeated
+func validateDomainNames(path *field.Path, domains []nginxpmoperatoriov1.DomainName) field.ErrorList {
+	var errs field.ErrorList
+
+	seen := map[string]bool{}
+
+	for i, domain := range domains {
+		domainPath := path.Index(i)
+		value := string(domain)
+
+		if !domainNamePattern.MatchString(value) {
+			errs = append(errs, field.Invalid(domainPath, value, "must be a valid domain name"))
+			continue
+		}
+
+		if seen[value] {
+			errs = append(errs, field.Duplicate(domainPath, value))
+		}
+		seen[value] = true
+	}
+
+	return errs
+}
+
+// validateForward checks that a forward has hosts or a service, and that their ports are valid
+func validateForward(path *field.Path, hosts []nginxpmoperatoriov1.ForwardHost, service *nginxpmoperatoriov1.ForwardService) field.ErrorList {
+	var errs field.ErrorList
+
+	if len(hosts) == 0 && service == nil {
+		errs = append(errs, field.Required(path, "one of hosts or service is required"))
+	}
+
+	for i, host := range hosts {
+		if err := validatePort(path.Child("hosts").Index(i).Child("hostPort"), int(host.HostPort)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if service != nil && service.Port != nil {
+		if err := validatePort(path.Child("service", "port"), int(*service.Port)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// validateCertificate rejects ssl settings referencing both an existing certificate id and a LetsEncryptCertificate
+func validateCertificate(path *field.Path, certificateId *int, letsEncryptCertificate *nginxpmoperatoriov1.SslLetsEncryptCertificate) field.ErrorList {
+	var errs field.ErrorList
+
+	if certificateId != nil && letsEncryptCertificate != nil {
+		errs = append(errs, field.Forbidden(path.Child("certificateId"),
+			fmt.Sprintf("may not be set together with %s", path.Child("letsEncryptCertificate"))))
+	}
+
+	return errs
+}
+
+// validateDeletionPolicy rejects the Restore policy on resources which never bind an existing object,
+// no initial configuration is captured for them
+func validateDeletionPolicy(path *field.Path, policy string, bindExisting bool) field.ErrorList {
+	var errs field.ErrorList
+
+	if policy == controller.DELETION_POLICY_RESTORE && !bindExisting {
+		errs = append(errs, field.Forbidden(path, "Restore requires bindExisting to be enabled"))
+	}
+
+	return errs
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+)
+
+func TestValidateDomainNames(t *testing.T) {
+	path := field.NewPath("spec", "domainNames")
+
+	valid := []nginxpmoperatoriov1.DomainName{"example.com", "*.example.com", "app.example.com"}
+	if errs := validateDomainNames(path, valid); len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+
+	// NPM rejects a port in the domains of a host
+	invalid := []nginxpmoperatoriov1.DomainName{"app.example.com:8443", "not a domain", "example.com", "example.com"}
+	if errs := validateDomainNames(path, invalid); len(errs) != 3 {
+		t.Errorf("Expected 3 errors, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestValidateProxyHost(t *testing.T) {
+	certificateId := 1
+
+	ph := &nginxpmoperatoriov1.ProxyHost{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: nginxpmoperatoriov1.ProxyHostSpec{
+			DomainNames: []nginxpmoperatoriov1.DomainName{"example.com"},
+			Ssl: &nginxpmoperatoriov1.ProxyHostSsl{
+				CertificateId:          &certificateId,
+				LetsEncryptCertificate: &nginxpmoperatoriov1.SslLetsEncryptCertificate{Name: "example"},
+			},
+		},
+	}
+
+	validator := &ProxyHostCustomValidator{}
+
+	if _, err := validator.ValidateCreate(context.Background(), ph); err == nil {
+		t.Error("Expected an error for a proxy host without forward and with two certificate sources")
+	}
+
+	ph.Spec.Ssl.LetsEncryptCertificate = nil
+	ph.Spec.Forward.Hosts = []nginxpmoperatoriov1.ForwardHost{{HostName: "10.0.0.1", HostPort: 8080}}
+	ph.Spec.AccessList = &nginxpmoperatoriov1.ProxyHostAccessList{Name: "admin"}
+	ph.Spec.AccessRules = &nginxpmoperatoriov1.ProxyHostAccessRules{
+		Clients: []nginxpmoperatoriov1.ProxyHostAccessClient{{Address: "10.0.0.0/8", Directive: "allow"}},
+	}
+
+	if _, err := validator.ValidateCreate(context.Background(), ph); err == nil {
+		t.Error("Expected an error for a proxy host with both an access list and access rules")
+	}
+
+	ph.Spec.AccessList = nil
+
+	if _, err := validator.ValidateCreate(context.Background(), ph); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestValidateAccessList(t *testing.T) {
+	al := &nginxpmoperatoriov1.AccessList{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: nginxpmoperatoriov1.AccessListSpec{
+			Clients: []nginxpmoperatoriov1.AccessListClient{
+				{Address: "192.168.0.0/24", Directive: "allow"},
+				{Address: "10.0.0.1", Directive: "deny"},
+			},
+		},
+	}
+
+	if err := validateAccessList(al); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	al.Spec.Authorizations = []nginxpmoperatoriov1.AccessListAuthorization{{
+		Username: "admin",
+		Password: "secret",
+		PasswordSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "admin-password"},
+			Key:                  "password",
+		},
+	}}
+
+	if err := validateAccessList(al); err == nil {
+		t.Error("Expected an error for a password set together with passwordSecretRef")
+	}
+
+	if warnings := accessListWarnings(al); len(warnings) != 1 {
+		t.Errorf("Expected a warning for the inline password, got %v", warnings)
+	}
+
+	al.Spec.Authorizations[0].Password = ""
+
+	if err := validateAccessList(al); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	al.Spec.Clients = append(al.Spec.Clients, nginxpmoperatoriov1.AccessListClient{
+		Directive: "allow",
+		Source:    &nginxpmoperatoriov1.AccessListClientSource{PodCIDR: &nginxpmoperatoriov1.AccessListPodCIDRSource{}},
+	})
+
+	if err := validateAccessList(al); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	al.Spec.Clients[len(al.Spec.Clients)-1].Source.Service = &nginxpmoperatoriov1.AccessListServiceSource{Name: "ingress"}
+
+	if err := validateAccessList(al); err == nil {
+		t.Error("Expected an error for a client with two sources")
+	}
+
+	al.Spec.Clients = al.Spec.Clients[:len(al.Spec.Clients)-1]
+	al.Spec.Clients = append(al.Spec.Clients, nginxpmoperatoriov1.AccessListClient{Address: "10.0.0.0/33", Directive: "reject"})
+
+	if err := validateAccessList(al); err == nil {
+		t.Error("Expected an error for an invalid CIDR and directive")
+	}
+}
+
+func TestValidateStream(t *testing.T) {
+	st := &nginxpmoperatoriov1.Stream{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: nginxpmoperatoriov1.StreamSpec{
+			IncomingPort: 70000,
+			Forward: nginxpmoperatoriov1.StreamForward{
+				Hosts: []nginxpmoperatoriov1.ForwardHost{{HostName: "10.0.0.1", HostPort: 5432}},
+			},
+		},
+	}
+
+	if err := validateStream(st); err == nil {
+		t.Error("Expected an error for an incoming port outside 1-65535")
+	}
+
+	st.Spec.IncomingPort = 5432
+
+	if err := validateStream(st); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	st.Spec.DeletionPolicy = "Restore"
+
+	if err := validateStream(st); err == nil {
+		t.Error("Expected an error for the Restore deletion policy without bindExisting")
+	}
+
+	st.Spec.BindExisting = true
+
+	if err := validateStream(st); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestValidateClusterToken(t *testing.T) {
+	ct := &nginxpmoperatoriov1.ClusterToken{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec: nginxpmoperatoriov1.ClusterTokenSpec{
+			TokenSpec: nginxpmoperatoriov1.TokenSpec{
+				Endpoint: "https://npm.example.com:81",
+				Secret:   nginxpmoperatoriov1.Secret{SecretName: "nginxpm-secret"},
+			},
+			AllowedNamespaces: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Unknown"}},
+			},
+		},
+	}
+
+	if err := validateClusterToken(ct); err == nil {
+		t.Error("Expected an error for an invalid allowedNamespaces selector")
+	}
+
+	ct.Spec.AllowedNamespaces = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}
+
+	if err := validateClusterToken(ct); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -2,15 +2,20 @@ package nginxpm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 )
 
 type AccessList struct {
-	ID             int `json:"id"`
-	ProxyHostCount int `json:"proxy_host_count"`
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	SatisfyAny     bool               `json:"satisfy_any"`
+	PassAuth       bool               `json:"pass_auth"`
+	ProxyHostCount int                `json:"proxy_host_count"`
+	Items          []AccessListItem   `json:"items"`
+	Clients        []AccessListClient `json:"clients"`
 }
 
 type AccessListItem struct {
@@ -31,65 +36,78 @@ type AccessListRequestInput struct {
 	Clients    []AccessListClient `json:"clients"`
 }
 
-// DeleteAccessList deletes a access list by its ID.
-func (c *Client) DeleteAccessList(id int) error {
+// DeleteAccessListContext deletes a access list by its ID.
+func (c *Client) DeleteAccessListContext(ctx context.Context, id int) error {
 	endpoint := fmt.Sprintf("/api/nginx/access-lists/%d", id)
-	resp, err := c.doRequest(http.MethodDelete, endpoint, nil)
+	resp, err := c.doRequest(ctx, http.MethodDelete, endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("delete access list %d: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("delete access list %d: unexpected status code: %d", id, resp.StatusCode)
+		return fmt.Errorf("delete access list %d: %w", id, newAPIError(resp))
 	}
 
 	return nil
 }
 
-// FindAccessListByID searches for an existing access list by its ID.
-func (c *Client) FindAccessListByID(id int) (*AccessList, error) {
-	resp, err := c.doRequest(http.MethodGet, "/api/nginx/access-lists", nil)
-	if err != nil {
+// GetAccessListsContext returns all the access lists of the instance with their items and clients.
+func (c *Client) GetAccessListsContext(ctx context.Context) ([]AccessList, error) {
+	var accessLists []AccessList
+	if err := c.getList(ctx, "/api/nginx/access-lists?expand=items,clients", &accessLists); err != nil {
 		return nil, fmt.Errorf("get access lists: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get access lists: unexpected status code: %d", resp.StatusCode)
-	}
+	return accessLists, nil
+}
 
-	var accesses []AccessList
-	if err := json.NewDecoder(resp.Body).Decode(&accesses); err != nil {
-		return nil, fmt.Errorf("get access lists: decode response: %w", err)
+// FindAccessListByNameContext searches for an existing access list by its name.
+func (c *Client) FindAccessListByNameContext(ctx context.Context, name string) (*AccessList, error) {
+	accessLists, err := c.GetAccessListsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find access list by name: %w", err)
 	}
 
-	for _, access := range accesses {
-		if access.ID == id {
-			return &access, nil
+	for _, accessList := range accessLists {
+		if accessList.Name == name {
+			return &accessList, nil
 		}
 	}
 
 	return nil, nil // No matching access list found
 }
 
-// CreateAccessList creates a new access list.
-func (c *Client) CreateAccessList(input AccessListRequestInput) (*AccessList, error) {
+// FindAccessListByIDContext searches for an existing access list by its ID.
+func (c *Client) FindAccessListByIDContext(ctx context.Context, id int) (*AccessList, error) {
+	var access AccessList
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/access-lists/%d?expand=items,clients", id), &access)
+	if err != nil {
+		return nil, fmt.Errorf("get access list %d: %w", id, err)
+	}
+
+	if !found {
+		return nil, nil // No matching access list found
+	}
+
+	return &access, nil
+}
+
+// CreateAccessListContext creates a new access list.
+func (c *Client) CreateAccessListContext(ctx context.Context, input AccessListRequestInput) (*AccessList, error) {
 	jsonBody, err := json.Marshal(input)
 	if err != nil {
 		return nil, fmt.Errorf("create access list: marshal request: %w", err)
 	}
 
-	resp, err := c.doRequest(http.MethodPost, "/api/nginx/access-lists", bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, http.MethodPost, "/api/nginx/access-lists", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("create access list: request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("create access list: unexpected status code: %d, body: %s",
-			resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("create access list: %w", newAPIError(resp))
 	}
 
 	var newAccessList AccessList
@@ -100,24 +118,22 @@ func (c *Client) CreateAccessList(input AccessListRequestInput) (*AccessList, er
 	return &newAccessList, nil
 }
 
-// UpdateAccessList updates an existing access list.
-func (c *Client) UpdateAccessList(id int, input AccessListRequestInput) (*AccessList, error) {
+// UpdateAccessListContext updates an existing access list.
+func (c *Client) UpdateAccessListContext(ctx context.Context, id int, input AccessListRequestInput) (*AccessList, error) {
 	jsonBody, err := json.Marshal(input)
 	if err != nil {
 		return nil, fmt.Errorf("update access list %d: marshal request: %w", id, err)
 	}
 
 	endpoint := fmt.Sprintf("/api/nginx/access-lists/%d", id)
-	resp, err := c.doRequest(http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("update access list %d: request failed: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("update access list %d: unexpected status code: %d, body: %s",
-			id, resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("update access list %d: %w", id, newAPIError(resp))
 	}
 
 	var updatedAccessList AccessList
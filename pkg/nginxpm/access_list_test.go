@@ -0,0 +1,64 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindAccessListByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/nginx/access-lists" {
+			t.Errorf("Expected request to '/api/nginx/access-lists', got '%s'", r.URL.Path)
+		}
+
+		if r.URL.Query().Get("expand") != "items,clients" {
+			t.Errorf("Expected the items and clients to be expanded, got '%s'", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]AccessList{
+			{ID: 1, Name: "internal"},
+			{ID: 2, Name: "admins", Items: []AccessListItem{{Username: "admin"}}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	accessList, err := client.FindAccessListByNameContext(context.Background(), "admins")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if accessList == nil || accessList.ID != 2 || len(accessList.Items) != 1 {
+		t.Errorf("Expected the access list 2 with its items, got %+v", accessList)
+	}
+
+	accessList, err = client.FindAccessListByNameContext(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if accessList != nil {
+		t.Errorf("Expected no access list, got %+v", accessList)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"sync"
+	"time"
+)
+
+// DEFAULT_LIST_CACHE_TTL is how long a client reuses the collections it listed
+const DEFAULT_LIST_CACHE_TTL = 5 * time.Second
+
+// listCache keeps the response bodies of the collection endpoints listed by a client,
+// so the lookups of several reconciles in a row do not download the same collection again.
+type listCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedList
+}
+
+type cachedList struct {
+	body    []byte
+	expires time.Time
+}
+
+func newListCache() *listCache {
+	return &listCache{entries: map[string]cachedList{}}
+}
+
+func (l *listCache) get(endpoint string) ([]byte, bool) {
+	if l == nil {
+		return nil, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[endpoint]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+
+	return entry.body, true
+}
+
+func (l *listCache) set(endpoint string, body []byte, ttl time.Duration) {
+	if l == nil || ttl <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[endpoint] = cachedList{body: body, expires: time.Now().Add(ttl)}
+}
+
+// clear drops every cached collection, it is called after each write request of the client
+func (l *listCache) clear() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = map[string]cachedList{}
+}
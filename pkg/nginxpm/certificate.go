@@ -17,7 +17,7 @@ limitations under the License.
 package nginxpm
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"github.com/paradoxe35/nginxpm-operator/pkg/util"
@@ -42,46 +42,34 @@ type certificate[K LetsEncryptCertificateMeta | CustomCertificateMeta | interfac
 
 type Certificate certificate[interface{}]
 
-// GetCertificates returns a list of certificates from the API
-func (c *Client) GetCertificates() ([]Certificate, error) {
-	resp, err := c.doRequest("GET", "/api/nginx/certificates", nil)
-
-	if err != nil {
-		return nil, fmt.Errorf("[GetCertificates] error querying certificates: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[GetCertificates] unexpected status code: %d", resp.StatusCode)
-	}
-
+// GetCertificatesContext returns a list of certificates from the API
+func (c *Client) GetCertificatesContext(ctx context.Context) ([]Certificate, error) {
 	var certificates []Certificate
-	if err := json.NewDecoder(resp.Body).Decode(&certificates); err != nil {
-		return nil, fmt.Errorf("[GetCertificates] error decoding response: %w", err)
+	if err := c.getList(ctx, "/api/nginx/certificates", &certificates); err != nil {
+		return nil, fmt.Errorf("[GetCertificates] error querying certificates: %w", err)
 	}
 
 	return certificates, nil
 }
 
-func (c *Client) FindCertificateByID(id int) (*Certificate, error) {
-	certificates, err := c.GetCertificates()
+func (c *Client) FindCertificateByIDContext(ctx context.Context, id int) (*Certificate, error) {
+	var cert Certificate
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/certificates/%d", id), &cert)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("[FindCertificateByID] error querying certificate: %w", err)
 	}
 
-	for _, cert := range certificates {
-		if cert.ID == id {
-			cert.Bound = false
-			return &cert, nil
-		}
+	if !found {
+		return nil, nil // No matching certificate found
 	}
 
-	return nil, nil // No matching certificate found
+	cert.Bound = false
+	return &cert, nil
 }
 
-// FindCertificateByDomain searches for an existing certificate matching the given domain
+// FindCertificateByDomainContext searches for an existing certificate matching the given domain
 // It will just match the first domain name in the list
-func (c *Client) FindCertificateByDomain(domains []string) (*Certificate, error) {
+func (c *Client) FindCertificateByDomainContext(ctx context.Context, domains []string) (*Certificate, error) {
 	if len(domains) == 0 {
 		return nil, fmt.Errorf("[FindCertificateByDomain] no domains provided")
 	}
@@ -89,7 +77,7 @@ func (c *Client) FindCertificateByDomain(domains []string) (*Certificate, error)
 	domain := domains[0]
 	rootDomain := util.ExtractRootDomain(domain)
 
-	certificates, err := c.GetCertificates()
+	certificates, err := c.GetCertificatesContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -18,17 +18,23 @@ package nginxpm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+
 	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
 )
 
 const (
 	NGINX_LB_SERVER_PREFIX = "xlb"
+
+	// Key of the JWT in the secrets written for Token resources
+	TOKEN_SECRET_KEY = "token"
 )
 
 // Shared Custom fields
@@ -49,12 +55,6 @@ type RequestCustomField struct {
 
 type RequestCustomFields map[string]RequestCustomField
 
-// APIError represents an error returned by the API
-type APIError struct {
-	StatusCode int
-	Body       string
-}
-
 // Client represents the NGINX Proxy Manager API client.
 // It contains the HTTP client, API endpoint, and authentication token.
 type Client struct {
@@ -62,6 +62,14 @@ type Client struct {
 	Endpoint   string
 	Token      string
 	Expires    time.Time
+
+	// Retry configures the retries of idempotent requests
+	Retry RetryPolicy
+
+	// ListCacheTTL is how long listed collections are reused by lookups, caching is disabled when zero
+	ListCacheTTL time.Duration
+
+	lists *listCache
 }
 
 // TokenResponse represents the structure of the token response from the API.
@@ -71,44 +79,53 @@ type TokenResponse struct {
 	Expires time.Time `json:"expires"`
 }
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("API error: status code %d, body: %s", e.StatusCode, e.Body)
-}
-
 // NewClient creates a new instance of the NGINX Proxy Manager client.
 // It takes the API endpoint as a parameter and sets up a default HTTP client with a timeout.
 func NewClient(httpClient *http.Client, endpoint string) *Client {
 	return &Client{
-		Endpoint:   endpoint,
-		httpClient: httpClient,
+		Endpoint:     endpoint,
+		httpClient:   httpClient,
+		Retry:        DefaultRetryPolicy,
+		ListCacheTTL: DEFAULT_LIST_CACHE_TTL,
+		lists:        newListCache(),
 	}
 }
 
 // NewClientFromToken creates a new client instance with a pre-existing token.
+// The token is read from tokenSecret, the Secret referenced by the status of the Token resource.
 // This is useful when you already have a valid token and don't need to authenticate.
-func NewClientFromToken(httpClient *http.Client, token *nginxpmoperatoriov1.Token) *Client {
+func NewClientFromToken(httpClient *http.Client, token *nginxpmoperatoriov1.Token, tokenSecret *corev1.Secret) *Client {
+	return NewClientFromTokenStatus(httpClient, token.Spec.Endpoint, token.Status, tokenSecret)
+}
+
+// NewClientFromTokenStatus creates a new client instance from the token issued for a Token or
+// ClusterToken resource, read from tokenSecret, and the expiration time of their status.
+func NewClientFromTokenStatus(httpClient *http.Client, endpoint string, status nginxpmoperatoriov1.TokenStatus, tokenSecret *corev1.Secret) *Client {
 	var tokenValue string
-	if token.Status.Token != nil {
-		tokenValue = *token.Status.Token
+	if tokenSecret != nil {
+		tokenValue = string(tokenSecret.Data[TOKEN_SECRET_KEY])
 	}
 
 	var expiresValue time.Time
-	if token.Status.Expires != nil {
-		expiresValue = token.Status.Expires.Time
+	if status.Expires != nil {
+		expiresValue = status.Expires.Time
 	}
 
 	return &Client{
-		Endpoint:   token.Spec.Endpoint,
-		Token:      tokenValue,
-		Expires:    expiresValue,
-		httpClient: httpClient,
+		Endpoint:     endpoint,
+		Token:        tokenValue,
+		Expires:      expiresValue,
+		httpClient:   httpClient,
+		Retry:        DefaultRetryPolicy,
+		ListCacheTTL: DEFAULT_LIST_CACHE_TTL,
+		lists:        newListCache(),
 	}
 }
 
-// CreateClientToken creates a new token for the client.
+// CreateClientTokenContext creates a new token for the client.
 // It takes the identity and secret as parameters and sends a POST request to the /api/tokens endpoint.
 // It returns an error if the request fails or if the response status code is not 200.
-func CreateClientToken(client *Client, identity string, secret string) error {
+func CreateClientTokenContext(ctx context.Context, client *Client, identity string, secret string) error {
 	payload := map[string]string{
 		"identity": identity,
 		"secret":   secret,
@@ -119,7 +136,7 @@ func CreateClientToken(client *Client, identity string, secret string) error {
 		return fmt.Errorf("[/api/tokens] Error marshaling payload: %w", err)
 	}
 
-	resp, err := client.doRequest(http.MethodPost, "/api/tokens", bytes.NewBuffer(jsonPayload))
+	resp, err := client.doRequest(ctx, http.MethodPost, "/api/tokens", bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return err
 	}
@@ -131,7 +148,7 @@ func CreateClientToken(client *Client, identity string, secret string) error {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("[/api/tokens] unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("[/api/tokens] %w", &APIError{StatusCode: resp.StatusCode, Body: string(body)})
 	}
 
 	var tokenResponse TokenResponse
@@ -146,12 +163,95 @@ func CreateClientToken(client *Client, identity string, secret string) error {
 	return nil
 }
 
+// RefreshClientTokenContext refreshes the token of the client before it expires.
+// It sends a GET request to the /api/tokens endpoint with the current token, which must still be valid,
+// and stores the new token and its expiration time in the client.
+func RefreshClientTokenContext(ctx context.Context, client *Client) error {
+	if client.Token == "" {
+		return fmt.Errorf("[/api/tokens] no token to refresh")
+	}
+
+	resp, err := client.doRequest(ctx, http.MethodGet, "/api/tokens", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("[/api/tokens] error reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("[/api/tokens] %w", &APIError{StatusCode: resp.StatusCode, Body: string(body)})
+	}
+
+	var tokenResponse TokenResponse
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return fmt.Errorf("[/api/tokens] error unmarshaling response: %w", err)
+	}
+
+	client.Token = tokenResponse.Token
+	client.Expires = tokenResponse.Expires
+
+	return nil
+}
+
 // doRequest is a helper method that performs HTTP requests to the API.
 // It sets up common headers, handles authentication, and performs the actual HTTP request.
-func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response, error) {
+// Idempotent requests are retried according to the Retry policy of the client,
+// until the attempts are exhausted or ctx is done.
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	// The body is kept to be sent again on retries
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+	}
+
+	// Listed collections may not be up to date anymore after a write
+	if method != http.MethodGet && method != http.MethodHead {
+		defer c.lists.clear()
+	}
+
+	attempts := 1
+	if isIdempotent(method) && c.Retry.Attempts > 1 {
+		attempts = c.Retry.Attempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendRequest(ctx, method, path, payload)
+		if attempt >= attempts || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(c.Retry.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("error sending request: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// sendRequest performs a single attempt of a request
+func (c *Client) sendRequest(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
 	url := c.Endpoint + path
 
-	req, err := http.NewRequest(method, url, body)
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
@@ -171,10 +271,63 @@ func (c *Client) doRequest(method, path string, body io.Reader) (*http.Response,
 	return resp, nil
 }
 
-// CheckConnection sends a GET request to the /api endpoint to verify connectivity.
+// getByID performs a GET request on the endpoint of a single resource and decodes the response into v.
+// It returns false when the resource does not exist.
+func (c *Client) getByID(ctx context.Context, endpoint string, v interface{}) (bool, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, newAPIError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("decode response: %w", err)
+	}
+
+	return true, nil
+}
+
+// getList performs a GET request on a collection endpoint and decodes the response into v.
+// Responses are reused for ListCacheTTL, until a write request is made by the client.
+func (c *Client) getList(ctx context.Context, endpoint string, v interface{}) error {
+	body, ok := c.lists.get(endpoint)
+	if !ok {
+		resp, err := c.doRequest(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp)
+		}
+
+		if body, err = io.ReadAll(resp.Body); err != nil {
+			return fmt.Errorf("read response: %w", err)
+		}
+
+		c.lists.set(endpoint, body, c.ListCacheTTL)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// CheckConnectionContext sends a GET request to the /api endpoint to verify connectivity.
 // It returns nil if the connection is successful, or an error if it fails.
-func (c *Client) CheckConnection() error {
-	resp, err := c.doRequest(http.MethodGet, "/api/", nil)
+func (c *Client) CheckConnectionContext(ctx context.Context) error {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/", nil)
 
 	if err != nil {
 		return fmt.Errorf("[/api/] Error checking connection: %w", err)
@@ -183,7 +336,7 @@ func (c *Client) CheckConnection() error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("[/api/] Unexpected status code: %d", resp.StatusCode)
+		return fmt.Errorf("[/api/] %w", newAPIError(resp))
 	}
 
 	return nil
@@ -191,15 +344,15 @@ func (c *Client) CheckConnection() error {
 
 // Check token user is valid
 // It returns an error if it fails.
-func (c *Client) CheckTokenAccess() error {
-	resp, err := c.doRequest(http.MethodGet, "/api/users/me", nil)
+func (c *Client) CheckTokenAccessContext(ctx context.Context) error {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/users/me", nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("[/api/users/me] unexpected status code: %d", resp.StatusCode)
+		return fmt.Errorf("[/api/users/me] %w", newAPIError(resp))
 	}
 
 	return nil
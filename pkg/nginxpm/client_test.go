@@ -17,11 +17,18 @@ limitations under the License.
 package nginxpm
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
 )
 
 func TestCreateClientToken(t *testing.T) {
@@ -123,6 +130,47 @@ func TestCreateClientTokenError(t *testing.T) {
 	}
 }
 
+func TestRefreshClientToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected 'GET' request, got '%s'", r.Method)
+		}
+
+		if r.URL.Path != "/api/tokens" {
+			t.Errorf("Expected request to '/api/tokens', got '%s'", r.URL.Path)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer old-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(TokenResponse{
+			Token:   "new-token",
+			Expires: time.Now().Add(time.Hour),
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+	client.Token = "old-token"
+
+	if err := RefreshClientToken(client); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if client.Token != "new-token" {
+		t.Errorf("Expected token 'new-token', got '%s'", client.Token)
+	}
+
+	// The refreshed token is no longer accepted by the mock server
+	if err := RefreshClientToken(client); err == nil {
+		t.Error("Expected an error, but got nil")
+	}
+}
+
 func TestCheckConnection(t *testing.T) {
 	// Test successful connection
 	t.Run("Successful Connection", func(t *testing.T) {
@@ -159,7 +207,7 @@ func TestCheckConnection(t *testing.T) {
 			t.Fatal("Expected an error, but got nil")
 		}
 
-		if err.Error() != "[/api/] Unexpected status code: 500" {
+		if err.Error() != "[/api/] unexpected status code: 500" {
 			t.Errorf("Unexpected error message: %v", err)
 		}
 	})
@@ -180,3 +228,55 @@ func TestCheckConnection(t *testing.T) {
 		}
 	})
 }
+
+func TestNewClientFromToken(t *testing.T) {
+	expires := metav1.NewTime(time.Now().Add(time.Hour))
+
+	token := &nginxpmoperatoriov1.Token{
+		Spec:   nginxpmoperatoriov1.TokenSpec{Endpoint: "https://npm.example.com"},
+		Status: nginxpmoperatoriov1.TokenStatus{Expires: &expires},
+	}
+
+	tokenSecret := &corev1.Secret{
+		Data: map[string][]byte{TOKEN_SECRET_KEY: []byte("test-token")},
+	}
+
+	client := NewClientFromToken(http.DefaultClient, token, tokenSecret)
+
+	if client.Token != "test-token" {
+		t.Errorf("Expected token 'test-token', got '%s'", client.Token)
+	}
+
+	if !client.Expires.Equal(expires.Time) {
+		t.Errorf("Expected expiration time %v, got %v", expires.Time, client.Expires)
+	}
+
+	if client.Endpoint != "https://npm.example.com" {
+		t.Errorf("Expected endpoint 'https://npm.example.com', got '%s'", client.Endpoint)
+	}
+}
+
+func TestRequestContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.Client(), server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.CheckConnectionContext(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline exceeded error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the request to stop with the context, took %v", elapsed)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"context"
+)
+
+// The functions below keep the signatures used before context propagation,
+// they call their Context variant with a background context.
+
+// CheckConnection calls CheckConnectionContext with a background context.
+func (c *Client) CheckConnection() error {
+	return c.CheckConnectionContext(context.Background())
+}
+
+// CheckTokenAccess calls CheckTokenAccessContext with a background context.
+func (c *Client) CheckTokenAccess() error {
+	return c.CheckTokenAccessContext(context.Background())
+}
+
+// CreateClientToken calls CreateClientTokenContext with a background context.
+func CreateClientToken(client *Client, identity string, secret string) error {
+	return CreateClientTokenContext(context.Background(), client, identity, secret)
+}
+
+// RefreshClientToken calls RefreshClientTokenContext with a background context.
+func RefreshClientToken(client *Client) error {
+	return RefreshClientTokenContext(context.Background(), client)
+}
+
+// DeleteAccessList calls DeleteAccessListContext with a background context.
+func (c *Client) DeleteAccessList(id int) error {
+	return c.DeleteAccessListContext(context.Background(), id)
+}
+
+// FindAccessListByID calls FindAccessListByIDContext with a background context.
+func (c *Client) FindAccessListByID(id int) (*AccessList, error) {
+	return c.FindAccessListByIDContext(context.Background(), id)
+}
+
+// CreateAccessList calls CreateAccessListContext with a background context.
+func (c *Client) CreateAccessList(input AccessListRequestInput) (*AccessList, error) {
+	return c.CreateAccessListContext(context.Background(), input)
+}
+
+// UpdateAccessList calls UpdateAccessListContext with a background context.
+func (c *Client) UpdateAccessList(id int, input AccessListRequestInput) (*AccessList, error) {
+	return c.UpdateAccessListContext(context.Background(), id, input)
+}
+
+// GetCertificates calls GetCertificatesContext with a background context.
+func (c *Client) GetCertificates() ([]Certificate, error) {
+	return c.GetCertificatesContext(context.Background())
+}
+
+// FindCertificateByID calls FindCertificateByIDContext with a background context.
+func (c *Client) FindCertificateByID(id int) (*Certificate, error) {
+	return c.FindCertificateByIDContext(context.Background(), id)
+}
+
+// FindCertificateByDomain calls FindCertificateByDomainContext with a background context.
+func (c *Client) FindCertificateByDomain(domains []string) (*Certificate, error) {
+	return c.FindCertificateByDomainContext(context.Background(), domains)
+}
+
+// CreateEmptyCustomCertificate calls CreateEmptyCustomCertificateContext with a background context.
+func (c *Client) CreateEmptyCustomCertificate(name string) (*CustomCertificate, error) {
+	return c.CreateEmptyCustomCertificateContext(context.Background(), name)
+}
+
+// ValidateCustomCertificate calls ValidateCustomCertificateContext with a background context.
+func (c *Client) ValidateCustomCertificate(certificateContent, certificateKeyContent []byte) (*CertificateValidationResponse, error) {
+	return c.ValidateCustomCertificateContext(context.Background(), certificateContent, certificateKeyContent)
+}
+
+// UploadCustomCertificate calls UploadCustomCertificateContext with a background context.
+func (c *Client) UploadCustomCertificate(id int, certificateContent, certificateKeyContent []byte) (*CertificateUploadResponse, error) {
+	return c.UploadCustomCertificateContext(context.Background(), id, certificateContent, certificateKeyContent)
+}
+
+// GetCustomCertificates calls GetCustomCertificatesContext with a background context.
+func (c *Client) GetCustomCertificates() ([]CustomCertificate, error) {
+	return c.GetCustomCertificatesContext(context.Background())
+}
+
+// FindCustomCertificateByID calls FindCustomCertificateByIDContext with a background context.
+func (c *Client) FindCustomCertificateByID(id int) (*CustomCertificate, error) {
+	return c.FindCustomCertificateByIDContext(context.Background(), id)
+}
+
+// FindCustomCertificateByName calls FindCustomCertificateByNameContext with a background context.
+func (c *Client) FindCustomCertificateByName(name string) (*CustomCertificate, error) {
+	return c.FindCustomCertificateByNameContext(context.Background(), name)
+}
+
+// CreateCustomCertificate calls CreateCustomCertificateContext with a background context.
+func (c *Client) CreateCustomCertificate(data CreateCustomCertificateRequest) (*CustomCertificate, error) {
+	return c.CreateCustomCertificateContext(context.Background(), data)
+}
+
+// DeleteDeadHost calls DeleteDeadHostContext with a background context.
+func (c *Client) DeleteDeadHost(id int) error {
+	return c.DeleteDeadHostContext(context.Background(), id)
+}
+
+// DisableDeadHost calls DisableDeadHostContext with a background context.
+func (c *Client) DisableDeadHost(id int) error {
+	return c.DisableDeadHostContext(context.Background(), id)
+}
+
+// EnableDeadHost calls EnableDeadHostContext with a background context.
+func (c *Client) EnableDeadHost(id int) error {
+	return c.EnableDeadHostContext(context.Background(), id)
+}
+
+// FindDeadHostByDomain calls FindDeadHostByDomainContext with a background context.
+func (c *Client) FindDeadHostByDomain(domains []string) (*DeadHost, error) {
+	return c.FindDeadHostByDomainContext(context.Background(), domains)
+}
+
+// FindDeadHostByID calls FindDeadHostByIDContext with a background context.
+func (c *Client) FindDeadHostByID(id int) (*DeadHost, error) {
+	return c.FindDeadHostByIDContext(context.Background(), id)
+}
+
+// CreateDeadHost calls CreateDeadHostContext with a background context.
+func (c *Client) CreateDeadHost(input DeadHostRequestInput) (*DeadHost, error) {
+	return c.CreateDeadHostContext(context.Background(), input)
+}
+
+// UpdateDeadHost calls UpdateDeadHostContext with a background context.
+func (c *Client) UpdateDeadHost(id int, input DeadHostRequestInput) (*DeadHost, error) {
+	return c.UpdateDeadHostContext(context.Background(), id, input)
+}
+
+// FindLetEncryptCertificate calls FindLetEncryptCertificateContext with a background context.
+func (c *Client) FindLetEncryptCertificate(domain string) (*LetsEncryptCertificate, error) {
+	return c.FindLetEncryptCertificateContext(context.Background(), domain)
+}
+
+// FindLetEncryptCertificateByID calls FindLetEncryptCertificateByIDContext with a background context.
+func (c *Client) FindLetEncryptCertificateByID(id int) (*LetsEncryptCertificate, error) {
+	return c.FindLetEncryptCertificateByIDContext(context.Background(), id)
+}
+
+// CreateLetEncryptCertificate calls CreateLetEncryptCertificateContext with a background context.
+func (c *Client) CreateLetEncryptCertificate(data CreateLetEncryptCertificateRequest) (*LetsEncryptCertificate, error) {
+	return c.CreateLetEncryptCertificateContext(context.Background(), data)
+}
+
+// DeleteCertificate calls DeleteCertificateContext with a background context.
+func (c *Client) DeleteCertificate(id int) error {
+	return c.DeleteCertificateContext(context.Background(), id)
+}
+
+// DeleteProxyHost calls DeleteProxyHostContext with a background context.
+func (c *Client) DeleteProxyHost(id int) error {
+	return c.DeleteProxyHostContext(context.Background(), id)
+}
+
+// DisableProxyHost calls DisableProxyHostContext with a background context.
+func (c *Client) DisableProxyHost(id int) error {
+	return c.DisableProxyHostContext(context.Background(), id)
+}
+
+// EnableProxyHost calls EnableProxyHostContext with a background context.
+func (c *Client) EnableProxyHost(id int) error {
+	return c.EnableProxyHostContext(context.Background(), id)
+}
+
+// FindProxyHostByDomain calls FindProxyHostByDomainContext with a background context.
+func (c *Client) FindProxyHostByDomain(domains []string) (*ProxyHost, error) {
+	return c.FindProxyHostByDomainContext(context.Background(), domains)
+}
+
+// FindProxyHostByID calls FindProxyHostByIDContext with a background context.
+func (c *Client) FindProxyHostByID(id int) (*ProxyHost, error) {
+	return c.FindProxyHostByIDContext(context.Background(), id)
+}
+
+// CreateProxyHost calls CreateProxyHostContext with a background context.
+func (c *Client) CreateProxyHost(input ProxyHostRequestInput) (*ProxyHost, error) {
+	return c.CreateProxyHostContext(context.Background(), input)
+}
+
+// UpdateProxyHost calls UpdateProxyHostContext with a background context.
+func (c *Client) UpdateProxyHost(id int, input ProxyHostRequestInput) (*ProxyHost, error) {
+	return c.UpdateProxyHostContext(context.Background(), id, input)
+}
+
+// DeleteRedirectionHost calls DeleteRedirectionHostContext with a background context.
+func (c *Client) DeleteRedirectionHost(id int) error {
+	return c.DeleteRedirectionHostContext(context.Background(), id)
+}
+
+// DisableRedirectionHost calls DisableRedirectionHostContext with a background context.
+func (c *Client) DisableRedirectionHost(id int) error {
+	return c.DisableRedirectionHostContext(context.Background(), id)
+}
+
+// EnableRedirectionHost calls EnableRedirectionHostContext with a background context.
+func (c *Client) EnableRedirectionHost(id int) error {
+	return c.EnableRedirectionHostContext(context.Background(), id)
+}
+
+// FindRedirectionHostByDomain calls FindRedirectionHostByDomainContext with a background context.
+func (c *Client) FindRedirectionHostByDomain(domains []string) (*RedirectionHost, error) {
+	return c.FindRedirectionHostByDomainContext(context.Background(), domains)
+}
+
+// FindRedirectionHostByID calls FindRedirectionHostByIDContext with a background context.
+func (c *Client) FindRedirectionHostByID(id int) (*RedirectionHost, error) {
+	return c.FindRedirectionHostByIDContext(context.Background(), id)
+}
+
+// CreateRedirectionHost calls CreateRedirectionHostContext with a background context.
+func (c *Client) CreateRedirectionHost(input RedirectionHostRequestInput) (*RedirectionHost, error) {
+	return c.CreateRedirectionHostContext(context.Background(), input)
+}
+
+// UpdateRedirectionHost calls UpdateRedirectionHostContext with a background context.
+func (c *Client) UpdateRedirectionHost(id int, input RedirectionHostRequestInput) (*RedirectionHost, error) {
+	return c.UpdateRedirectionHostContext(context.Background(), id, input)
+}
+
+// DeleteStream calls DeleteStreamContext with a background context.
+func (c *Client) DeleteStream(id int) error {
+	return c.DeleteStreamContext(context.Background(), id)
+}
+
+// FindStreamByID calls FindStreamByIDContext with a background context.
+func (c *Client) FindStreamByID(id int) (*Stream, error) {
+	return c.FindStreamByIDContext(context.Background(), id)
+}
+
+// CreateStream calls CreateStreamContext with a background context.
+func (c *Client) CreateStream(input StreamRequestInput) (*Stream, error) {
+	return c.CreateStreamContext(context.Background(), input)
+}
+
+// UpdateStream calls UpdateStreamContext with a background context.
+func (c *Client) UpdateStream(id int, input StreamRequestInput) (*Stream, error) {
+	return c.UpdateStreamContext(context.Background(), id, input)
+}
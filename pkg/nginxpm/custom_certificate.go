@@ -18,6 +18,7 @@ package nginxpm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"mime/multipart"
@@ -66,8 +67,8 @@ type CreateCustomCertificateRequest struct {
 	CertificateKey []byte `json:"certificate_key"`
 }
 
-// CreateEmptyCustomCertificate creates an empty custom certificate for later upload
-func (c *Client) CreateEmptyCustomCertificate(name string) (*CustomCertificate, error) {
+// CreateEmptyCustomCertificateContext creates an empty custom certificate for later upload
+func (c *Client) CreateEmptyCustomCertificateContext(ctx context.Context, name string) (*CustomCertificate, error) {
 	body := map[string]interface{}{
 		"nice_name": name,
 		"provider":  CUSTOM_PROVIDER,
@@ -78,14 +79,14 @@ func (c *Client) CreateEmptyCustomCertificate(name string) (*CustomCertificate,
 		return nil, fmt.Errorf("error marshaling request body: %w", err)
 	}
 
-	resp, err := c.doRequest("POST", "/api/nginx/certificates", bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, "POST", "/api/nginx/certificates", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("error creating certificate: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("unexpected status code when creating certificate: %d", resp.StatusCode)
+		return nil, fmt.Errorf("create certificate: %w", newAPIError(resp))
 	}
 
 	newCert := new(CustomCertificate)
@@ -126,14 +127,14 @@ func (c *Client) certificateFilesFromBytes(certificateContent, certificateKeyCon
 	return body, writer, nil
 }
 
-// ValidateCustomCertificate validates a certificate and its key
-func (c *Client) ValidateCustomCertificate(certificateContent, certificateKeyContent []byte) (*CertificateValidationResponse, error) {
+// ValidateCustomCertificateContext validates a certificate and its key
+func (c *Client) ValidateCustomCertificateContext(ctx context.Context, certificateContent, certificateKeyContent []byte) (*CertificateValidationResponse, error) {
 	body, writer, err := c.certificateFilesFromBytes(certificateContent, certificateKeyContent)
 	if err != nil {
 		return nil, fmt.Errorf("error preparing certificate files: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.Endpoint+"/api/nginx/certificates/validate", body)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint+"/api/nginx/certificates/validate", body)
 	if err != nil {
 		return nil, fmt.Errorf("error creating validation request: %w", err)
 	}
@@ -149,10 +150,7 @@ func (c *Client) ValidateCustomCertificate(certificateContent, certificateKeyCon
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf(
-			"unexpected status code during validation: %d, check certificate and key content",
-			resp.StatusCode,
-		)
+		return nil, fmt.Errorf("validate certificate: %w, check certificate and key content", newAPIError(resp))
 	}
 
 	var validationResponse CertificateValidationResponse
@@ -163,15 +161,15 @@ func (c *Client) ValidateCustomCertificate(certificateContent, certificateKeyCon
 	return &validationResponse, nil
 }
 
-// UploadCustomCertificate uploads a validated certificate and its key to a specific certificate ID
-func (c *Client) UploadCustomCertificate(id int, certificateContent, certificateKeyContent []byte) (*CertificateUploadResponse, error) {
+// UploadCustomCertificateContext uploads a validated certificate and its key to a specific certificate ID
+func (c *Client) UploadCustomCertificateContext(ctx context.Context, id int, certificateContent, certificateKeyContent []byte) (*CertificateUploadResponse, error) {
 	body, writer, err := c.certificateFilesFromBytes(certificateContent, certificateKeyContent)
 	if err != nil {
 		return nil, fmt.Errorf("error preparing certificate files for upload: %w", err)
 	}
 
 	url := fmt.Sprintf("/api/nginx/certificates/%d/upload", id)
-	req, err := http.NewRequest("POST", c.Endpoint+url, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint+url, body)
 	if err != nil {
 		return nil, fmt.Errorf("error creating upload request: %w", err)
 	}
@@ -187,7 +185,7 @@ func (c *Client) UploadCustomCertificate(id int, certificateContent, certificate
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code during upload: %d", resp.StatusCode)
+		return nil, fmt.Errorf("upload certificate: %w", newAPIError(resp))
 	}
 
 	var uploadResponse CertificateUploadResponse
@@ -198,46 +196,35 @@ func (c *Client) UploadCustomCertificate(id int, certificateContent, certificate
 	return &uploadResponse, nil
 }
 
-// GetCustomCertificates retrieves all certificates
-func (c *Client) GetCustomCertificates() ([]CustomCertificate, error) {
-	resp, err := c.doRequest("GET", "/api/nginx/certificates", nil)
-	if err != nil {
-		return nil, fmt.Errorf("error querying certificates: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code when fetching certificates: %d", resp.StatusCode)
-	}
-
+// GetCustomCertificatesContext retrieves all certificates
+func (c *Client) GetCustomCertificatesContext(ctx context.Context) ([]CustomCertificate, error) {
 	var certificates []CustomCertificate
-	if err := json.NewDecoder(resp.Body).Decode(&certificates); err != nil {
-		return nil, fmt.Errorf("error decoding certificates response: %w", err)
+	if err := c.getList(ctx, "/api/nginx/certificates", &certificates); err != nil {
+		return nil, fmt.Errorf("get certificates: %w", err)
 	}
 
 	return certificates, nil
 }
 
-// FindCustomCertificateByID retrieves a certificate by its ID
-func (c *Client) FindCustomCertificateByID(id int) (*CustomCertificate, error) {
-	certificates, err := c.GetCustomCertificates()
+// FindCustomCertificateByIDContext retrieves a certificate by its ID
+func (c *Client) FindCustomCertificateByIDContext(ctx context.Context, id int) (*CustomCertificate, error) {
+	var cert CustomCertificate
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/certificates/%d", id), &cert)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("get certificate %d: %w", id, err)
 	}
 
-	for _, cert := range certificates {
-		if cert.ID == id && cert.Provider == CUSTOM_PROVIDER {
-			cert.Bound = false
-			return &cert, nil
-		}
+	if !found || cert.Provider != CUSTOM_PROVIDER {
+		return nil, nil // No matching certificate found
 	}
 
-	return nil, nil // No matching certificate found
+	cert.Bound = false
+	return &cert, nil
 }
 
-// FindCustomCertificateByName retrieves a certificate by its name
-func (c *Client) FindCustomCertificateByName(name string) (*CustomCertificate, error) {
-	certificates, err := c.GetCustomCertificates()
+// FindCustomCertificateByNameContext retrieves a certificate by its name
+func (c *Client) FindCustomCertificateByNameContext(ctx context.Context, name string) (*CustomCertificate, error) {
+	certificates, err := c.GetCustomCertificatesContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -252,28 +239,28 @@ func (c *Client) FindCustomCertificateByName(name string) (*CustomCertificate, e
 	return nil, nil // No matching certificate found
 }
 
-// CreateCustomCertificate creates a new custom certificate with the provided data
-func (c *Client) CreateCustomCertificate(data CreateCustomCertificateRequest) (*CustomCertificate, error) {
+// CreateCustomCertificateContext creates a new custom certificate with the provided data
+func (c *Client) CreateCustomCertificateContext(ctx context.Context, data CreateCustomCertificateRequest) (*CustomCertificate, error) {
 	// Validate certificate and key
-	_, err := c.ValidateCustomCertificate(data.Certificate, data.CertificateKey)
+	_, err := c.ValidateCustomCertificateContext(ctx, data.Certificate, data.CertificateKey)
 	if err != nil {
 		return nil, fmt.Errorf("error validating certificate and key: %w", err)
 	}
 
 	// Create empty certificate
-	emptyCert, err := c.CreateEmptyCustomCertificate(data.NiceName)
+	emptyCert, err := c.CreateEmptyCustomCertificateContext(ctx, data.NiceName)
 	if err != nil {
 		return nil, fmt.Errorf("error creating empty certificate: %w", err)
 	}
 
 	// Upload certificate and key
-	_, err = c.UploadCustomCertificate(emptyCert.ID, data.Certificate, data.CertificateKey)
+	_, err = c.UploadCustomCertificateContext(ctx, emptyCert.ID, data.Certificate, data.CertificateKey)
 	if err != nil {
 		return nil, fmt.Errorf("error uploading certificate and key: %w", err)
 	}
 
 	// Find certificate by ID
-	cert, err := c.FindCustomCertificateByID(emptyCert.ID)
+	cert, err := c.FindCustomCertificateByIDContext(ctx, emptyCert.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error finding certificate by ID: %w", err)
 	}
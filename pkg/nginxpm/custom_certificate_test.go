@@ -392,16 +392,25 @@ func TestFindCustomCertificateByID(t *testing.T) {
 					t.Errorf("Expected 'GET' request, got '%s'", r.Method)
 				}
 
-				expectedPath := "/api/nginx/certificates"
+				expectedPath := fmt.Sprintf("/api/nginx/certificates/%d", tt.certificateID)
 				if r.URL.Path != expectedPath {
 					t.Errorf("Expected request to '%s', got '%s'", expectedPath, r.URL.Path)
 				}
 
 				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(tt.serverStatus)
-				if tt.serverResponse != nil {
-					json.NewEncoder(w).Encode(tt.serverResponse)
+				if tt.serverStatus != http.StatusOK {
+					w.WriteHeader(tt.serverStatus)
+					return
 				}
+
+				for _, cert := range tt.serverResponse {
+					if cert.ID == tt.certificateID {
+						json.NewEncoder(w).Encode(cert)
+						return
+					}
+				}
+
+				w.WriteHeader(http.StatusNotFound)
 			}))
 			defer server.Close()
 
@@ -0,0 +1,251 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DeadHost represents the structure of a dead (404) host as returned by the API.
+type DeadHost struct {
+	ID             int       `json:"id"`
+	CreatedOn      string    `json:"created_on"`
+	ModifiedOn     string    `json:"modified_on"`
+	OwnerUserID    int       `json:"owner_user_id"`
+	DomainNames    []string  `json:"domain_names"`
+	CertificateID  int       `json:"certificate_id"`
+	SSLForced      bool      `json:"ssl_forced"`
+	AdvancedConfig string    `json:"advanced_config"`
+	Meta           NginxMeta `json:"meta"`
+	HTTP2Support   bool      `json:"http2_support"`
+	Enabled        bool      `json:"enabled"`
+	HSTSEnabled    bool      `json:"hsts_enabled"`
+	HSTSSubdomains bool      `json:"hsts_subdomains"`
+}
+
+// DeadHostRequestInput holds all parameters needed to create a dead host.
+type DeadHostRequestInput struct {
+	DomainNames    []string
+	CertificateID  *int
+	SSLForced      bool
+	HTTP2Support   bool
+	HSTSEnabled    bool
+	HSTSSubdomains bool
+	AdvancedConfig string
+}
+
+// DeleteDeadHostContext deletes a dead host by its ID.
+func (c *Client) DeleteDeadHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindDeadHostByIDContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete dead host %d: %w", id, err)
+	}
+
+	if existing == nil {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/dead-hosts/%d", id)
+	resp, err := c.doRequest(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("delete dead host %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("delete dead host %d: %w", id, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// DisableDeadHostContext disable a dead host by its ID.
+func (c *Client) DisableDeadHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindDeadHostByIDContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("disable dead host %d: %w", id, err)
+	}
+
+	if existing == nil || !existing.Enabled {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/dead-hosts/%d/disable", id)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("disable dead host %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("disable dead host %d: %w", id, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// EnableDeadHostContext enable a dead host by its ID.
+func (c *Client) EnableDeadHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindDeadHostByIDContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("enable dead host %d: %w", id, err)
+	}
+
+	if existing == nil || existing.Enabled {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/dead-hosts/%d/enable", id)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("enable dead host %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("enable dead host %d: %w", id, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// FindDeadHostByDomainContext searches for an existing dead host matching the given domains.
+func (c *Client) FindDeadHostByDomainContext(ctx context.Context, domains []string) (*DeadHost, error) {
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("find dead host by domain: no domains provided")
+	}
+
+	query := url.QueryEscape(domains[0])
+	endpoint := fmt.Sprintf("/api/nginx/dead-hosts?query=%s", query)
+
+	hosts, err := c.getDeadHosts(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("find dead host by domain: %w", err)
+	}
+
+	for _, host := range hosts {
+		if !domainsMatch(host.DomainNames, domains) {
+			continue
+		}
+		return &host, nil
+	}
+
+	return nil, nil // No matching dead host found
+}
+
+// FindDeadHostByIDContext searches for an existing dead host by its ID.
+func (c *Client) FindDeadHostByIDContext(ctx context.Context, id int) (*DeadHost, error) {
+	var host DeadHost
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/dead-hosts/%d", id), &host)
+	if err != nil {
+		return nil, fmt.Errorf("find dead host by ID: %w", err)
+	}
+
+	if !found {
+		return nil, nil // No matching dead host found
+	}
+
+	return &host, nil
+}
+
+// CreateDeadHostContext creates a new dead host.
+func (c *Client) CreateDeadHostContext(ctx context.Context, input DeadHostRequestInput) (*DeadHost, error) {
+	jsonBody, err := json.Marshal(buildDeadHostRequestBody(input))
+	if err != nil {
+		return nil, fmt.Errorf("create dead host: marshal request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/api/nginx/dead-hosts", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("create dead host: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("create dead host: %w", newAPIError(resp))
+	}
+
+	var newDeadHost DeadHost
+	if err := json.NewDecoder(resp.Body).Decode(&newDeadHost); err != nil {
+		return nil, fmt.Errorf("create dead host: decode response: %w", err)
+	}
+
+	return &newDeadHost, nil
+}
+
+// UpdateDeadHostContext updates an existing dead host.
+func (c *Client) UpdateDeadHostContext(ctx context.Context, id int, input DeadHostRequestInput) (*DeadHost, error) {
+	jsonBody, err := json.Marshal(buildDeadHostRequestBody(input))
+	if err != nil {
+		return nil, fmt.Errorf("update dead host %d: marshal request: %w", id, err)
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/dead-hosts/%d", id)
+	resp, err := c.doRequest(ctx, http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("update dead host %d: request failed: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("update dead host %d: %w", id, newAPIError(resp))
+	}
+
+	var updatedDeadHost DeadHost
+	if err := json.NewDecoder(resp.Body).Decode(&updatedDeadHost); err != nil {
+		return nil, fmt.Errorf("update dead host %d: decode response: %w", id, err)
+	}
+
+	return &updatedDeadHost, nil
+}
+
+// getDeadHosts performs a GET request to fetch dead hosts.
+func (c *Client) getDeadHosts(ctx context.Context, endpoint string) ([]DeadHost, error) {
+	var hosts []DeadHost
+	if err := c.getList(ctx, endpoint, &hosts); err != nil {
+		return nil, fmt.Errorf("get dead hosts: %w", err)
+	}
+
+	return hosts, nil
+}
+
+// buildDeadHostRequestBody creates the request body for dead host operations.
+func buildDeadHostRequestBody(input DeadHostRequestInput) map[string]interface{} {
+	certificateID := 0
+	if input.CertificateID != nil {
+		certificateID = *input.CertificateID
+	}
+
+	return map[string]interface{}{
+		"domain_names":    input.DomainNames,
+		"certificate_id":  certificateID,
+		"ssl_forced":      input.SSLForced,
+		"http2_support":   input.HTTP2Support,
+		"hsts_enabled":    input.HSTSEnabled,
+		"hsts_subdomains": input.HSTSSubdomains,
+		"meta": map[string]interface{}{
+			"letsencrypt_agree": false,
+			"dns_challenge":     false,
+		},
+		"advanced_config": input.AdvancedConfig,
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdateDeadHost(t *testing.T) {
+	certificateID := 3
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected 'PUT' request, got '%s'", r.Method)
+		}
+
+		if r.URL.Path != "/api/nginx/dead-hosts/5" {
+			t.Errorf("Expected request to '/api/nginx/dead-hosts/5', got '%s'", r.URL.Path)
+		}
+
+		var requestBody map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&requestBody)
+
+		if requestBody["certificate_id"] != float64(certificateID) {
+			t.Errorf("Expected certificate_id %d, got '%v'", certificateID, requestBody["certificate_id"])
+		}
+
+		if requestBody["ssl_forced"] != true {
+			t.Errorf("Expected ssl_forced true, got '%v'", requestBody["ssl_forced"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(DeadHost{
+			ID:            5,
+			DomainNames:   []string{"old.example.com"},
+			CertificateID: certificateID,
+			SSLForced:     true,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	host, err := client.UpdateDeadHost(5, DeadHostRequestInput{
+		DomainNames:   []string{"old.example.com"},
+		CertificateID: &certificateID,
+		SSLForced:     true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if host.CertificateID != certificateID {
+		t.Errorf("Expected certificate ID %d, got %d", certificateID, host.CertificateID)
+	}
+}
+
+func TestDeleteDeadHostNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected only 'GET' requests when the dead host does not exist, got '%s'", r.Method)
+		}
+
+		if r.URL.Path != "/api/nginx/dead-hosts/42" {
+			t.Errorf("Expected request to '/api/nginx/dead-hosts/42', got '%s'", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	if err := client.DeleteDeadHost(42); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
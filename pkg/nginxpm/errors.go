@@ -0,0 +1,134 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// APIError represents an error returned by the API, the requests answered with an
+// unexpected status code return it wrapped with the operation which failed.
+// Use IsNotFound, IsUnauthorized, IsConflict and IsServerError to check its class.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("unexpected status code: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// newAPIError reads the body of a response with an unexpected status code into an APIError
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+
+	return &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+// StatusCode returns the status code of the APIError wrapped by err, 0 when there is none
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
+
+// IsNotFound reports whether the requested record does not exist in Nginx Proxy Manager
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether the token was rejected, usually because it expired.
+// A 403 means the token is valid but lacks a permission, it is not reported here.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsConflict reports whether the request conflicts with the current state of the record.
+// Validation errors, answered with a 400, are not conflicts.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsServerError reports whether Nginx Proxy Manager failed to handle the request, e.g. while restarting
+func IsServerError(err error) bool {
+	return StatusCode(err) >= http.StatusInternalServerError
+}
+
+// RetryPolicy configures the retries of the idempotent requests (GET, PUT and DELETE)
+// failing with a network error, a 429 or a 5xx status code.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts of a request, retries are disabled below 2
+	Attempts int
+
+	// BaseDelay is the delay before the first retry, doubled after each attempt
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of the clients created by this package
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:  3,
+	BaseDelay: 200 * time.Millisecond,
+	MaxDelay:  2 * time.Second,
+}
+
+// delay returns the jittered delay before the next attempt, between half and all of the backoff
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := p.BaseDelay << (attempt - 1)
+	if backoff <= 0 || (p.MaxDelay > 0 && backoff > p.MaxDelay) {
+		backoff = p.MaxDelay
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// shouldRetry reports whether a request which got resp or err is worth another attempt
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
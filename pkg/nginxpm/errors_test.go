@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIErrorClasses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/nginx/proxy-hosts/1":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/nginx/proxy-hosts":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "domain already in use"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	_, err := client.UpdateProxyHost(1, ProxyHostRequestInput{})
+	if !IsNotFound(err) || IsConflict(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	// A validation error is neither a conflict nor a rejected token
+	_, err = client.CreateProxyHost(ProxyHostRequestInput{})
+	if StatusCode(err) != http.StatusBadRequest || IsConflict(err) || IsUnauthorized(err) {
+		t.Errorf("Expected a bad request error, got %v", err)
+	}
+
+	if err := client.CheckTokenAccess(); !IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+	client.Retry = RetryPolicy{Attempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	if err := client.CheckConnection(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+
+	// POST requests are not retried
+	requests = 0
+	_, err := client.CreateStream(StreamRequestInput{})
+	if !IsServerError(err) || requests != 1 {
+		t.Errorf("Expected a single request failing with a server error, got %d requests and %v", requests, err)
+	}
+}
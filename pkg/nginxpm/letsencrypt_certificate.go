@@ -18,6 +18,7 @@ package nginxpm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -29,6 +30,7 @@ import (
 type LetsEncryptCertificateMeta struct {
 	LetsEncryptAgree bool    `json:"letsencrypt_agree"`
 	DNSChallenge     bool    `json:"dns_challenge"`
+	DNSProvider      string  `json:"dns_provider"`
 	NginxOnline      bool    `json:"nginx_online"`
 	NginxErr         *string `json:"nginx_err"`
 	LetsEncryptEmail string  `json:"letsencrypt_email"`
@@ -49,27 +51,33 @@ type CreateLetEncryptCertificateRequest struct {
 
 type LetsEncryptCertificate certificate[LetsEncryptCertificateMeta]
 
+// GetLetsEncryptCertificatesContext returns the certificates of the instance issued by Let's Encrypt
+func (c *Client) GetLetsEncryptCertificatesContext(ctx context.Context) ([]LetsEncryptCertificate, error) {
+	var certificates []LetsEncryptCertificate
+	if err := c.getList(ctx, "/api/nginx/certificates", &certificates); err != nil {
+		return nil, fmt.Errorf("[GetLetsEncryptCertificates] error querying certificates: %w", err)
+	}
+
+	letsEncryptCertificates := make([]LetsEncryptCertificate, 0, len(certificates))
+	for _, cert := range certificates {
+		if cert.Provider == LETSENCRYPT_PROVIDER {
+			letsEncryptCertificates = append(letsEncryptCertificates, cert)
+		}
+	}
+
+	return letsEncryptCertificates, nil
+}
+
 // FindExistingCertificate searches for an existing certificate matching the given domain
-func (c *Client) FindLetEncryptCertificate(domain string) (*LetsEncryptCertificate, error) {
+func (c *Client) FindLetEncryptCertificateContext(ctx context.Context, domain string) (*LetsEncryptCertificate, error) {
 	rootDomain := util.ExtractRootDomain(domain)
 
 	// URL encode the query parameter
 	query := url.QueryEscape(rootDomain)
 
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/nginx/certificates?query=%s", query), nil)
-
-	if err != nil {
-		return nil, fmt.Errorf("[FindLetEncryptCertificate] error querying certificates: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[FindLetEncryptCertificate] unexpected status code: %d", resp.StatusCode)
-	}
-
 	var certificates []LetsEncryptCertificate
-	if err := json.NewDecoder(resp.Body).Decode(&certificates); err != nil {
-		return nil, fmt.Errorf("[FindLetEncryptCertificate] error decoding response: %w", err)
+	if err := c.getList(ctx, fmt.Sprintf("/api/nginx/certificates?query=%s", query), &certificates); err != nil {
+		return nil, fmt.Errorf("[FindLetEncryptCertificate] error querying certificates: %w", err)
 	}
 
 	for _, cert := range certificates {
@@ -87,40 +95,28 @@ func (c *Client) FindLetEncryptCertificate(domain string) (*LetsEncryptCertifica
 	return nil, nil // No matching certificate found
 }
 
-// FindCertificateByID retrieves a certificate by its ID
-func (c *Client) FindLetEncryptCertificateByID(id int) (*LetsEncryptCertificate, error) {
-	resp, err := c.doRequest("GET", "/api/nginx/certificates", nil)
-
+// FindCertificateByIDContext retrieves a certificate by its ID
+func (c *Client) FindLetEncryptCertificateByIDContext(ctx context.Context, id int) (*LetsEncryptCertificate, error) {
+	var cert LetsEncryptCertificate
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/certificates/%d", id), &cert)
 	if err != nil {
-		return nil, fmt.Errorf("[FindLetEncryptCertificateByID] error querying certificates: %w", err)
+		return nil, fmt.Errorf("[FindLetEncryptCertificateByID] error querying certificate: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("[FindLetEncryptCertificateByID] unexpected status code: %d", resp.StatusCode)
+	if !found || cert.Provider != LETSENCRYPT_PROVIDER {
+		return nil, nil // No matching certificate found
 	}
 
-	var certificates []LetsEncryptCertificate
-	if err := json.NewDecoder(resp.Body).Decode(&certificates); err != nil {
-		return nil, fmt.Errorf("[FindLetEncryptCertificateByID] error decoding response: %w", err)
-	}
-
-	for _, cert := range certificates {
-		if cert.ID == id && cert.Provider == LETSENCRYPT_PROVIDER {
-			cert.Bound = false
-			return &cert, nil
-		}
-	}
-
-	return nil, nil // No matching certificate found
+	cert.Bound = false
+	return &cert, nil
 }
 
 // LetEncryptCertificate creates a new certificate for the given domains or returns an existing one if found
-func (c *Client) CreateLetEncryptCertificate(data CreateLetEncryptCertificateRequest) (*LetsEncryptCertificate, error) {
+func (c *Client) CreateLetEncryptCertificateContext(ctx context.Context, data CreateLetEncryptCertificateRequest) (*LetsEncryptCertificate, error) {
 	var existingCertificate *LetsEncryptCertificate
 
 	for i, domain := range data.DomainNames {
-		cert, err := c.FindLetEncryptCertificate(domain)
+		cert, err := c.FindLetEncryptCertificateContext(ctx, domain)
 		if err != nil {
 			return nil, fmt.Errorf("[CreateLetEncryptCertificate] error finding existing certificate for domain %s: %w", domain, err)
 		}
@@ -157,14 +153,14 @@ func (c *Client) CreateLetEncryptCertificate(data CreateLetEncryptCertificateReq
 		return nil, fmt.Errorf("[CreateLetEncryptCertificate] error marshaling request body: %w", err)
 	}
 
-	resp, err := c.doRequest("POST", "/api/nginx/certificates", bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, "POST", "/api/nginx/certificates", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("[CreateLetEncryptCertificate] error creating certificate: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("[CreateLetEncryptCertificate] unexpected status code when creating certificate: %d", resp.StatusCode)
+		return nil, fmt.Errorf("[CreateLetEncryptCertificate] %w", newAPIError(resp))
 	}
 
 	newCert := new(LetsEncryptCertificate)
@@ -178,16 +174,40 @@ func (c *Client) CreateLetEncryptCertificate(data CreateLetEncryptCertificateReq
 	return newCert, nil
 }
 
-// DeleteCertificate deletes a certificate by its ID
-func (c *Client) DeleteCertificate(id int) error {
-	resp, err := c.doRequest("DELETE", fmt.Sprintf("/api/nginx/certificates/%d", id), nil)
+// RenewLetsEncryptCertificateContext asks the instance to renew the certificate with the given ID now,
+// it returns the renewed certificate once Let's Encrypt has issued it
+func (c *Client) RenewLetsEncryptCertificateContext(ctx context.Context, id int) (*LetsEncryptCertificate, error) {
+	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/api/nginx/certificates/%d/renew", id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("[RenewLetsEncryptCertificate %d] error renewing certificate: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("[RenewLetsEncryptCertificate %d] %w", id, newAPIError(resp))
+	}
+
+	cert := new(LetsEncryptCertificate)
+
+	if err := json.NewDecoder(resp.Body).Decode(cert); err != nil {
+		return nil, fmt.Errorf("[RenewLetsEncryptCertificate %d] error decoding response: %w", id, err)
+	}
+
+	cert.Bound = false
+
+	return cert, nil
+}
+
+// DeleteCertificateContext deletes a certificate by its ID
+func (c *Client) DeleteCertificateContext(ctx context.Context, id int) error {
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/api/nginx/certificates/%d", id), nil)
 	if err != nil {
 		return fmt.Errorf("[DeleteCertificate %d] error deleting certificate: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("[DeleteCertificate %d] unexpected status code: %d", id, resp.StatusCode)
+		return fmt.Errorf("[DeleteCertificate %d] %w", id, newAPIError(resp))
 	}
 
 	return nil
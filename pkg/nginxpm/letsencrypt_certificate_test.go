@@ -17,6 +17,7 @@ limitations under the License.
 package nginxpm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -197,16 +198,25 @@ func TestFindLetEncryptCertificateByID(t *testing.T) {
 					t.Errorf("Expected 'GET' request, got '%s'", r.Method)
 				}
 
-				expectedPath := "/api/nginx/certificates"
+				expectedPath := fmt.Sprintf("/api/nginx/certificates/%d", tt.certificateID)
 				if r.URL.Path != expectedPath {
 					t.Errorf("Expected request to '%s', got '%s'", expectedPath, r.URL.Path)
 				}
 
 				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(tt.serverStatus)
-				if tt.serverResponse != nil {
-					json.NewEncoder(w).Encode(tt.serverResponse)
+				if tt.serverStatus != http.StatusOK {
+					w.WriteHeader(tt.serverStatus)
+					return
 				}
+
+				for _, cert := range tt.serverResponse {
+					if cert.ID == tt.certificateID {
+						json.NewEncoder(w).Encode(cert)
+						return
+					}
+				}
+
+				w.WriteHeader(http.StatusNotFound)
 			}))
 			defer server.Close()
 
@@ -406,6 +416,73 @@ func TestDeleteLetEncryptCertificate(t *testing.T) {
 	}
 }
 
+func TestRenewLetsEncryptCertificate(t *testing.T) {
+	now := time.Now()
+	renewedCert := LetsEncryptCertificate{
+		ID:          1,
+		CreatedOn:   now.Format(time.RFC3339),
+		ModifiedOn:  now.Format(time.RFC3339),
+		Provider:    LETSENCRYPT_PROVIDER,
+		NiceName:    "Example Cert",
+		DomainNames: []string{"example.com"},
+		ExpiresOn:   now.AddDate(0, 3, 0).Format(time.RFC3339),
+	}
+
+	tests := []struct {
+		name          string
+		certificateID int
+		serverStatus  int
+		expectError   bool
+	}{
+		{
+			name:          "Successful renewal",
+			certificateID: 1,
+			serverStatus:  http.StatusOK,
+			expectError:   false,
+		},
+		{
+			name:          "Renewal failed",
+			certificateID: 1,
+			serverStatus:  http.StatusBadRequest,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("Expected 'POST' request, got '%s'", r.Method)
+				}
+
+				expectedPath := fmt.Sprintf("/api/nginx/certificates/%d/renew", tt.certificateID)
+				if r.URL.Path != expectedPath {
+					t.Errorf("Expected request to '%s', got '%s'", expectedPath, r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverStatus == http.StatusOK {
+					json.NewEncoder(w).Encode(renewedCert)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(server.Client(), server.URL)
+
+			cert, err := client.RenewLetsEncryptCertificateContext(context.Background(), tt.certificateID)
+
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Unexpected error status: got error %v, expectError %v", err, tt.expectError)
+			}
+
+			if !tt.expectError {
+				compareLetsEncryptCertificates(t, &renewedCert, cert)
+			}
+		})
+	}
+}
+
 func compareLetsEncryptCertificates(t *testing.T, expected, actual *LetsEncryptCertificate) {
 	if expected.ID != actual.ID {
 		t.Errorf("Expected certificate ID %d, got %d", expected.ID, actual.ID)
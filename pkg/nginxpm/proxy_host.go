@@ -18,9 +18,9 @@ package nginxpm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 )
@@ -88,9 +88,9 @@ type ProxyHostRequestInput struct {
 	CustomFields          RequestCustomFields
 }
 
-// DeleteProxyHost deletes a proxy host by its ID.
-func (c *Client) DeleteProxyHost(id int) error {
-	existing, err := c.FindProxyHostByID(id)
+// DeleteProxyHostContext deletes a proxy host by its ID.
+func (c *Client) DeleteProxyHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindProxyHostByIDContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("delete proxy host %d: %w", id, err)
 	}
@@ -100,22 +100,22 @@ func (c *Client) DeleteProxyHost(id int) error {
 	}
 
 	endpoint := fmt.Sprintf("/api/nginx/proxy-hosts/%d", id)
-	resp, err := c.doRequest(http.MethodDelete, endpoint, nil)
+	resp, err := c.doRequest(ctx, http.MethodDelete, endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("delete proxy host %d: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("delete proxy host %d: unexpected status code: %d", id, resp.StatusCode)
+		return fmt.Errorf("delete proxy host %d: %w", id, newAPIError(resp))
 	}
 
 	return nil
 }
 
-// DisableProxyHost disable a proxy host by its ID.
-func (c *Client) DisableProxyHost(id int) error {
-	existing, err := c.FindProxyHostByID(id)
+// DisableProxyHostContext disable a proxy host by its ID.
+func (c *Client) DisableProxyHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindProxyHostByIDContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("disable proxy host %d: %w", id, err)
 	}
@@ -125,22 +125,22 @@ func (c *Client) DisableProxyHost(id int) error {
 	}
 
 	endpoint := fmt.Sprintf("/api/nginx/proxy-hosts/%d/disable", id)
-	resp, err := c.doRequest(http.MethodPost, endpoint, nil)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("disable proxy host %d: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("disable proxy host %d: unexpected status code: %d", id, resp.StatusCode)
+		return fmt.Errorf("disable proxy host %d: %w", id, newAPIError(resp))
 	}
 
 	return nil
 }
 
-// EnableProxyHost enable a proxy host by its ID.
-func (c *Client) EnableProxyHost(id int) error {
-	existing, err := c.FindProxyHostByID(id)
+// EnableProxyHostContext enable a proxy host by its ID.
+func (c *Client) EnableProxyHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindProxyHostByIDContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("enable proxy host %d: %w", id, err)
 	}
@@ -150,21 +150,21 @@ func (c *Client) EnableProxyHost(id int) error {
 	}
 
 	endpoint := fmt.Sprintf("/api/nginx/proxy-hosts/%d/enable", id)
-	resp, err := c.doRequest(http.MethodPost, endpoint, nil)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("enable proxy host %d: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("enable proxy host %d: unexpected status code: %d", id, resp.StatusCode)
+		return fmt.Errorf("enable proxy host %d: %w", id, newAPIError(resp))
 	}
 
 	return nil
 }
 
-// FindProxyHostByDomain searches for an existing proxy host matching the given domains.
-func (c *Client) FindProxyHostByDomain(domains []string) (*ProxyHost, error) {
+// FindProxyHostByDomainContext searches for an existing proxy host matching the given domains.
+func (c *Client) FindProxyHostByDomainContext(ctx context.Context, domains []string) (*ProxyHost, error) {
 	if len(domains) == 0 {
 		return nil, fmt.Errorf("find proxy host by domain: no domains provided")
 	}
@@ -172,7 +172,7 @@ func (c *Client) FindProxyHostByDomain(domains []string) (*ProxyHost, error) {
 	query := url.QueryEscape(domains[0])
 	endpoint := fmt.Sprintf("/api/nginx/proxy-hosts?query=%s", query)
 
-	hosts, err := c.getProxyHosts(endpoint)
+	hosts, err := c.getProxyHosts(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("find proxy host by domain: %w", err)
 	}
@@ -188,39 +188,41 @@ func (c *Client) FindProxyHostByDomain(domains []string) (*ProxyHost, error) {
 	return nil, nil // No matching proxy host found
 }
 
-// FindProxyHostByID searches for an existing proxy host by its ID.
-func (c *Client) FindProxyHostByID(id int) (*ProxyHost, error) {
-	hosts, err := c.getProxyHosts("/api/nginx/proxy-hosts")
+// GetProxyHostsContext returns all the proxy hosts of the instance.
+func (c *Client) GetProxyHostsContext(ctx context.Context) ([]ProxyHost, error) {
+	return c.getProxyHosts(ctx, "/api/nginx/proxy-hosts")
+}
+
+// FindProxyHostByIDContext searches for an existing proxy host by its ID.
+func (c *Client) FindProxyHostByIDContext(ctx context.Context, id int) (*ProxyHost, error) {
+	var host ProxyHost
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/proxy-hosts/%d", id), &host)
 	if err != nil {
 		return nil, fmt.Errorf("find proxy host by ID: %w", err)
 	}
 
-	for _, host := range hosts {
-		if host.ID == id {
-			return &host, nil
-		}
+	if !found {
+		return nil, nil // No matching proxy host found
 	}
 
-	return nil, nil // No matching proxy host found
+	return &host, nil
 }
 
-// CreateProxyHost creates a new proxy host.
-func (c *Client) CreateProxyHost(input ProxyHostRequestInput) (*ProxyHost, error) {
+// CreateProxyHostContext creates a new proxy host.
+func (c *Client) CreateProxyHostContext(ctx context.Context, input ProxyHostRequestInput) (*ProxyHost, error) {
 	jsonBody, err := json.Marshal(buildProxyHostRequestBody(input))
 	if err != nil {
 		return nil, fmt.Errorf("create proxy host: marshal request: %w", err)
 	}
 
-	resp, err := c.doRequest(http.MethodPost, "/api/nginx/proxy-hosts", bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, http.MethodPost, "/api/nginx/proxy-hosts", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("create proxy host: request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("create proxy host: unexpected status code: %d, body: %s",
-			resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("create proxy host: %w", newAPIError(resp))
 	}
 
 	var newProxyHost ProxyHost
@@ -231,24 +233,22 @@ func (c *Client) CreateProxyHost(input ProxyHostRequestInput) (*ProxyHost, error
 	return &newProxyHost, nil
 }
 
-// UpdateProxyHost updates an existing proxy host.
-func (c *Client) UpdateProxyHost(id int, input ProxyHostRequestInput) (*ProxyHost, error) {
+// UpdateProxyHostContext updates an existing proxy host.
+func (c *Client) UpdateProxyHostContext(ctx context.Context, id int, input ProxyHostRequestInput) (*ProxyHost, error) {
 	jsonBody, err := json.Marshal(buildProxyHostRequestBody(input))
 	if err != nil {
 		return nil, fmt.Errorf("update proxy host %d: marshal request: %w", id, err)
 	}
 
 	endpoint := fmt.Sprintf("/api/nginx/proxy-hosts/%d", id)
-	resp, err := c.doRequest(http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("update proxy host %d: request failed: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("update proxy host %d: unexpected status code: %d, body: %s",
-			id, resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("update proxy host %d: %w", id, newAPIError(resp))
 	}
 
 	var updatedProxyHost ProxyHost
@@ -262,20 +262,10 @@ func (c *Client) UpdateProxyHost(id int, input ProxyHostRequestInput) (*ProxyHos
 // Helper functions
 
 // getProxyHosts performs a GET request to fetch proxy hosts.
-func (c *Client) getProxyHosts(endpoint string) ([]ProxyHost, error) {
-	resp, err := c.doRequest(http.MethodGet, endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("get proxy hosts: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get proxy hosts: unexpected status code: %d", resp.StatusCode)
-	}
-
+func (c *Client) getProxyHosts(ctx context.Context, endpoint string) ([]ProxyHost, error) {
 	var hosts []ProxyHost
-	if err := json.NewDecoder(resp.Body).Decode(&hosts); err != nil {
-		return nil, fmt.Errorf("get proxy hosts: decode response: %w", err)
+	if err := c.getList(ctx, endpoint, &hosts); err != nil {
+		return nil, fmt.Errorf("get proxy hosts: %w", err)
 	}
 
 	return hosts, nil
@@ -15,3 +15,60 @@ limitations under the License.
 */
 
 package nginxpm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindProxyHostListCache(t *testing.T) {
+	listRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/nginx/proxy-hosts":
+			listRequests++
+			json.NewEncoder(w).Encode([]ProxyHost{{ID: 3, DomainNames: []string{"example.com"}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/nginx/proxy-hosts/3":
+			json.NewEncoder(w).Encode(ProxyHost{ID: 3, DomainNames: []string{"example.com"}, Enabled: true})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/nginx/proxy-hosts/3/disable":
+			w.Write([]byte("true"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	for i := 0; i < 2; i++ {
+		host, err := client.FindProxyHostByDomain([]string{"example.com"})
+		if err != nil || host == nil || host.ID != 3 {
+			t.Fatalf("Expected proxy host 3, got %+v, %v", host, err)
+		}
+	}
+
+	if listRequests != 1 {
+		t.Errorf("Expected the second lookup to be served from the cache, got %d list requests", listRequests)
+	}
+
+	if err := client.DisableProxyHost(3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.FindProxyHostByDomain([]string{"example.com"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if listRequests != 2 {
+		t.Errorf("Expected a write to drop the cached lists, got %d list requests", listRequests)
+	}
+
+	host, err := client.FindProxyHostByID(4)
+	if err != nil || host != nil {
+		t.Errorf("Expected no proxy host for a 404, got %+v, %v", host, err)
+	}
+}
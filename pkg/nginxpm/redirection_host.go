@@ -0,0 +1,266 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// RedirectionHost represents the structure of a redirection host as returned by the API.
+type RedirectionHost struct {
+	ID                int       `json:"id"`
+	CreatedOn         string    `json:"created_on"`
+	ModifiedOn        string    `json:"modified_on"`
+	OwnerUserID       int       `json:"owner_user_id"`
+	DomainNames       []string  `json:"domain_names"`
+	ForwardScheme     string    `json:"forward_scheme"`
+	ForwardDomainName string    `json:"forward_domain_name"`
+	ForwardHTTPCode   int       `json:"forward_http_code"`
+	PreservePath      bool      `json:"preserve_path"`
+	CertificateID     int       `json:"certificate_id"`
+	SSLForced         bool      `json:"ssl_forced"`
+	BlockExploits     bool      `json:"block_exploits"`
+	AdvancedConfig    string    `json:"advanced_config"`
+	Meta              NginxMeta `json:"meta"`
+	HTTP2Support      bool      `json:"http2_support"`
+	Enabled           bool      `json:"enabled"`
+	HSTSEnabled       bool      `json:"hsts_enabled"`
+	HSTSSubdomains    bool      `json:"hsts_subdomains"`
+}
+
+// RedirectionHostRequestInput holds all parameters needed to create a redirection host.
+type RedirectionHostRequestInput struct {
+	DomainNames       []string
+	ForwardScheme     string
+	ForwardDomainName string
+	ForwardHTTPCode   int
+	PreservePath      bool
+	BlockExploits     bool
+	CertificateID     *int
+	SSLForced         bool
+	HTTP2Support      bool
+	HSTSEnabled       bool
+	HSTSSubdomains    bool
+	AdvancedConfig    string
+}
+
+// DeleteRedirectionHostContext deletes a redirection host by its ID.
+func (c *Client) DeleteRedirectionHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindRedirectionHostByIDContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete redirection host %d: %w", id, err)
+	}
+
+	if existing == nil {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/redirection-hosts/%d", id)
+	resp, err := c.doRequest(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("delete redirection host %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("delete redirection host %d: %w", id, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// DisableRedirectionHostContext disable a redirection host by its ID.
+func (c *Client) DisableRedirectionHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindRedirectionHostByIDContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("disable redirection host %d: %w", id, err)
+	}
+
+	if existing == nil || !existing.Enabled {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/redirection-hosts/%d/disable", id)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("disable redirection host %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("disable redirection host %d: %w", id, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// EnableRedirectionHostContext enable a redirection host by its ID.
+func (c *Client) EnableRedirectionHostContext(ctx context.Context, id int) error {
+	existing, err := c.FindRedirectionHostByIDContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("enable redirection host %d: %w", id, err)
+	}
+
+	if existing == nil || existing.Enabled {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/redirection-hosts/%d/enable", id)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("enable redirection host %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("enable redirection host %d: %w", id, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// FindRedirectionHostByDomainContext searches for an existing redirection host matching the given domains.
+func (c *Client) FindRedirectionHostByDomainContext(ctx context.Context, domains []string) (*RedirectionHost, error) {
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("find redirection host by domain: no domains provided")
+	}
+
+	query := url.QueryEscape(domains[0])
+	endpoint := fmt.Sprintf("/api/nginx/redirection-hosts?query=%s", query)
+
+	hosts, err := c.getRedirectionHosts(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("find redirection host by domain: %w", err)
+	}
+
+	for _, host := range hosts {
+		if !domainsMatch(host.DomainNames, domains) {
+			continue
+		}
+		return &host, nil
+	}
+
+	return nil, nil // No matching redirection host found
+}
+
+// FindRedirectionHostByIDContext searches for an existing redirection host by its ID.
+func (c *Client) FindRedirectionHostByIDContext(ctx context.Context, id int) (*RedirectionHost, error) {
+	var host RedirectionHost
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/redirection-hosts/%d", id), &host)
+	if err != nil {
+		return nil, fmt.Errorf("find redirection host by ID: %w", err)
+	}
+
+	if !found {
+		return nil, nil // No matching redirection host found
+	}
+
+	return &host, nil
+}
+
+// CreateRedirectionHostContext creates a new redirection host.
+func (c *Client) CreateRedirectionHostContext(ctx context.Context, input RedirectionHostRequestInput) (*RedirectionHost, error) {
+	jsonBody, err := json.Marshal(buildRedirectionHostRequestBody(input))
+	if err != nil {
+		return nil, fmt.Errorf("create redirection host: marshal request: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/api/nginx/redirection-hosts", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("create redirection host: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("create redirection host: %w", newAPIError(resp))
+	}
+
+	var newRedirectionHost RedirectionHost
+	if err := json.NewDecoder(resp.Body).Decode(&newRedirectionHost); err != nil {
+		return nil, fmt.Errorf("create redirection host: decode response: %w", err)
+	}
+
+	return &newRedirectionHost, nil
+}
+
+// UpdateRedirectionHostContext updates an existing redirection host.
+func (c *Client) UpdateRedirectionHostContext(ctx context.Context, id int, input RedirectionHostRequestInput) (*RedirectionHost, error) {
+	jsonBody, err := json.Marshal(buildRedirectionHostRequestBody(input))
+	if err != nil {
+		return nil, fmt.Errorf("update redirection host %d: marshal request: %w", id, err)
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/redirection-hosts/%d", id)
+	resp, err := c.doRequest(ctx, http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("update redirection host %d: request failed: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("update redirection host %d: %w", id, newAPIError(resp))
+	}
+
+	var updatedRedirectionHost RedirectionHost
+	if err := json.NewDecoder(resp.Body).Decode(&updatedRedirectionHost); err != nil {
+		return nil, fmt.Errorf("update redirection host %d: decode response: %w", id, err)
+	}
+
+	return &updatedRedirectionHost, nil
+}
+
+// getRedirectionHosts performs a GET request to fetch redirection hosts.
+func (c *Client) getRedirectionHosts(ctx context.Context, endpoint string) ([]RedirectionHost, error) {
+	var hosts []RedirectionHost
+	if err := c.getList(ctx, endpoint, &hosts); err != nil {
+		return nil, fmt.Errorf("get redirection hosts: %w", err)
+	}
+
+	return hosts, nil
+}
+
+// buildRedirectionHostRequestBody creates the request body for redirection host operations.
+func buildRedirectionHostRequestBody(input RedirectionHostRequestInput) map[string]interface{} {
+	certificateID := 0
+	if input.CertificateID != nil {
+		certificateID = *input.CertificateID
+	}
+
+	return map[string]interface{}{
+		"domain_names":        input.DomainNames,
+		"forward_scheme":      input.ForwardScheme,
+		"forward_domain_name": input.ForwardDomainName,
+		"forward_http_code":   input.ForwardHTTPCode,
+		"preserve_path":       input.PreservePath,
+		"block_exploits":      input.BlockExploits,
+		"certificate_id":      certificateID,
+		"ssl_forced":          input.SSLForced,
+		"http2_support":       input.HTTP2Support,
+		"hsts_enabled":        input.HSTSEnabled,
+		"hsts_subdomains":     input.HSTSSubdomains,
+		"meta": map[string]interface{}{
+			"letsencrypt_agree": false,
+			"dns_challenge":     false,
+		},
+		"advanced_config": input.AdvancedConfig,
+	}
+}
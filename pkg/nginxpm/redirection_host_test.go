@@ -0,0 +1,109 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateRedirectionHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected 'POST' request, got '%s'", r.Method)
+		}
+
+		if r.URL.Path != "/api/nginx/redirection-hosts" {
+			t.Errorf("Expected request to '/api/nginx/redirection-hosts', got '%s'", r.URL.Path)
+		}
+
+		var requestBody map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&requestBody)
+
+		if requestBody["forward_domain_name"] != "www.example.com" {
+			t.Errorf("Expected forward_domain_name 'www.example.com', got '%v'", requestBody["forward_domain_name"])
+		}
+
+		if requestBody["forward_http_code"] != float64(301) {
+			t.Errorf("Expected forward_http_code 301, got '%v'", requestBody["forward_http_code"])
+		}
+
+		if requestBody["certificate_id"] != float64(0) {
+			t.Errorf("Expected certificate_id 0, got '%v'", requestBody["certificate_id"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(RedirectionHost{
+			ID:                7,
+			DomainNames:       []string{"example.com"},
+			ForwardScheme:     "auto",
+			ForwardDomainName: "www.example.com",
+			ForwardHTTPCode:   301,
+			Enabled:           true,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	host, err := client.CreateRedirectionHost(RedirectionHostRequestInput{
+		DomainNames:       []string{"example.com"},
+		ForwardScheme:     "auto",
+		ForwardDomainName: "www.example.com",
+		ForwardHTTPCode:   301,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if host.ID != 7 {
+		t.Errorf("Expected redirection host ID 7, got %d", host.ID)
+	}
+}
+
+func TestFindRedirectionHostByDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") != "example.com" {
+			t.Errorf("Expected query 'example.com', got '%s'", r.URL.Query().Get("query"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]RedirectionHost{
+			{ID: 1, DomainNames: []string{"example.com", "old.example.com"}},
+			{ID: 2, DomainNames: []string{"example.com"}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	host, err := client.FindRedirectionHostByDomain([]string{"example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if host == nil || host.ID != 2 {
+		t.Fatalf("Expected redirection host with ID 2, got %+v", host)
+	}
+
+	if _, err := client.FindRedirectionHostByDomain(nil); err == nil {
+		t.Error("Expected an error when no domains are provided")
+	}
+}
@@ -2,14 +2,21 @@ package nginxpm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 )
 
 type Stream struct {
 	ID             int       `json:"id"`
+	IncomingPort   int       `json:"incoming_port"`
+	ForwardingHost string    `json:"forwarding_host"`
+	ForwardingPort int       `json:"forwarding_port"`
+	TCPForwarding  bool      `json:"tcp_forwarding"`
+	UDPForwarding  bool      `json:"udp_forwarding"`
+	CertificateID  int       `json:"certificate_id"`
+	Enabled        bool      `json:"enabled"`
 	UnscopedConfig *string   `json:"unscoped_config"` // Custom field from https://github.com/paradoxe35/nginx-proxy-manager, it must be a string pointer
 	Meta           NginxMeta `json:"meta"`
 }
@@ -24,41 +31,91 @@ type StreamRequestInput struct {
 	CustomFields   RequestCustomFields
 }
 
-// DeleteStream deletes a stream by its ID.
-func (c *Client) DeleteStream(id int) error {
+// DeleteStreamContext deletes a stream by its ID.
+func (c *Client) DeleteStreamContext(ctx context.Context, id int) error {
 	endpoint := fmt.Sprintf("/api/nginx/streams/%d", id)
-	resp, err := c.doRequest(http.MethodDelete, endpoint, nil)
+	resp, err := c.doRequest(ctx, http.MethodDelete, endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("delete stream %d: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("delete stream %d: unexpected status code: %d", id, resp.StatusCode)
+		return fmt.Errorf("delete stream %d: %w", id, newAPIError(resp))
 	}
 
 	return nil
 }
 
-// FindStreamByID searches for an existing stream by its ID.
-func (c *Client) FindStreamByID(id int) (*Stream, error) {
-	resp, err := c.doRequest(http.MethodGet, "/api/nginx/streams", nil)
+// DisableStreamContext disable a stream by its ID.
+func (c *Client) DisableStreamContext(ctx context.Context, id int) error {
+	existing, err := c.FindStreamByIDContext(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("get streams: %w", err)
+		return fmt.Errorf("disable stream %d: %w", id, err)
+	}
+
+	if existing == nil || !existing.Enabled {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/streams/%d/disable", id)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("disable stream %d: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get streams: unexpected status code: %d", resp.StatusCode)
+		return fmt.Errorf("disable stream %d: %w", id, newAPIError(resp))
 	}
 
+	return nil
+}
+
+// EnableStreamContext enable a stream by its ID.
+func (c *Client) EnableStreamContext(ctx context.Context, id int) error {
+	existing, err := c.FindStreamByIDContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("enable stream %d: %w", id, err)
+	}
+
+	if existing == nil || existing.Enabled {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("/api/nginx/streams/%d/enable", id)
+	resp, err := c.doRequest(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("enable stream %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("enable stream %d: %w", id, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// GetStreamsContext returns all the streams of the instance.
+func (c *Client) GetStreamsContext(ctx context.Context) ([]Stream, error) {
 	var streams []Stream
-	if err := json.NewDecoder(resp.Body).Decode(&streams); err != nil {
-		return nil, fmt.Errorf("get streams: decode response: %w", err)
+	if err := c.getList(ctx, "/api/nginx/streams", &streams); err != nil {
+		return nil, fmt.Errorf("get streams: %w", err)
+	}
+
+	return streams, nil
+}
+
+// FindStreamByIncomingPortContext searches for an existing stream listening on the given port.
+func (c *Client) FindStreamByIncomingPortContext(ctx context.Context, port int) (*Stream, error) {
+	streams, err := c.GetStreamsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find stream by incoming port: %w", err)
 	}
 
 	for _, stream := range streams {
-		if stream.ID == id {
+		if stream.IncomingPort == port {
 			return &stream, nil
 		}
 	}
@@ -66,23 +123,36 @@ func (c *Client) FindStreamByID(id int) (*Stream, error) {
 	return nil, nil // No matching stream found
 }
 
-// CreateStream creates a new stream.
-func (c *Client) CreateStream(input StreamRequestInput) (*Stream, error) {
+// FindStreamByIDContext searches for an existing stream by its ID.
+func (c *Client) FindStreamByIDContext(ctx context.Context, id int) (*Stream, error) {
+	var stream Stream
+	found, err := c.getByID(ctx, fmt.Sprintf("/api/nginx/streams/%d", id), &stream)
+	if err != nil {
+		return nil, fmt.Errorf("get stream %d: %w", id, err)
+	}
+
+	if !found {
+		return nil, nil // No matching stream found
+	}
+
+	return &stream, nil
+}
+
+// CreateStreamContext creates a new stream.
+func (c *Client) CreateStreamContext(ctx context.Context, input StreamRequestInput) (*Stream, error) {
 	jsonBody, err := json.Marshal(buildStreamRequestBody(input))
 	if err != nil {
 		return nil, fmt.Errorf("create stream: marshal request: %w", err)
 	}
 
-	resp, err := c.doRequest(http.MethodPost, "/api/nginx/streams", bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, http.MethodPost, "/api/nginx/streams", bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("create stream: request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("create stream: unexpected status code: %d, body: %s",
-			resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("create stream: %w", newAPIError(resp))
 	}
 
 	var newStream Stream
@@ -93,24 +163,22 @@ func (c *Client) CreateStream(input StreamRequestInput) (*Stream, error) {
 	return &newStream, nil
 }
 
-// UpdateStream updates an existing stream.
-func (c *Client) UpdateStream(id int, input StreamRequestInput) (*Stream, error) {
+// UpdateStreamContext updates an existing stream.
+func (c *Client) UpdateStreamContext(ctx context.Context, id int, input StreamRequestInput) (*Stream, error) {
 	jsonBody, err := json.Marshal(buildStreamRequestBody(input))
 	if err != nil {
 		return nil, fmt.Errorf("update stream %d: marshal request: %w", id, err)
 	}
 
 	endpoint := fmt.Sprintf("/api/nginx/streams/%d", id)
-	resp, err := c.doRequest(http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
+	resp, err := c.doRequest(ctx, http.MethodPut, endpoint, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("update stream %d: request failed: %w", id, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("update stream %d: unexpected status code: %d, body: %s",
-			id, resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("update stream %d: %w", id, newAPIError(resp))
 	}
 
 	var updatedStream Stream
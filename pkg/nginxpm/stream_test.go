@@ -0,0 +1,60 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nginxpm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindStreamByIncomingPort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/nginx/streams" {
+			t.Errorf("Expected request to '/api/nginx/streams', got '%s'", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Stream{
+			{ID: 1, IncomingPort: 5432, ForwardingHost: "10.0.0.1", ForwardingPort: 5432},
+			{ID: 2, IncomingPort: 6379, ForwardingHost: "10.0.0.2", ForwardingPort: 6379},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL)
+
+	stream, err := client.FindStreamByIncomingPortContext(context.Background(), 6379)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stream == nil || stream.ID != 2 {
+		t.Errorf("Expected the stream 2, got %+v", stream)
+	}
+
+	stream, err = client.FindStreamByIncomingPortContext(context.Background(), 3306)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stream != nil {
+		t.Errorf("Expected no stream, got %+v", stream)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakenpm serves an in-memory Nginx Proxy Manager API to the controller tests
+package fakenpm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	nginxpmoperatoriov1 "github.com/paradoxe35/nginxpm-operator/api/v1"
+	"github.com/paradoxe35/nginxpm-operator/pkg/nginxpm"
+)
+
+// Server stores the objects of the /api/nginx/<collection> endpoints, such as proxy-hosts or
+// redirection-hosts, as the JSON objects written to them
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	nextID  int
+	objects map[string]map[int]map[string]interface{}
+}
+
+// NewServer starts a server without objects, it must be closed by the test
+func NewServer() *Server {
+	s := &Server{objects: map[string]map[int]map[string]interface{}{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// Add stores an object in the collection and returns its id
+func (s *Server) Add(collection string, object map[string]interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.add(collection, object)
+}
+
+// Get returns the object of the collection with the given id, nil when there is none
+func (s *Server) Get(collection string, id int) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.objects[collection][id]
+}
+
+// Token returns a Token of the server and the Secret holding its JWT. The uid of the Token
+// is unique to the server, so the pooled clients of other servers are not reused.
+func (s *Server) Token(name, namespace string) []client.Object {
+	secretName := name + "-jwt"
+
+	token := &nginxpmoperatoriov1.Token{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID("fakenpm-" + s.URL)},
+		Spec:       nginxpmoperatoriov1.TokenSpec{Endpoint: s.URL},
+		Status: nginxpmoperatoriov1.TokenStatus{
+			TokenSecretRef: &corev1.SecretReference{Name: secretName, Namespace: namespace},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+		Data:       map[string][]byte{nginxpm.TOKEN_SECRET_KEY: []byte("jwt")},
+	}
+
+	return []client.Object{token, secret}
+}
+
+func (s *Server) add(collection string, object map[string]interface{}) int {
+	if s.objects[collection] == nil {
+		s.objects[collection] = map[int]map[string]interface{}{}
+	}
+
+	s.nextID++
+	object["id"] = s.nextID
+	if _, ok := object["enabled"]; !ok {
+		object["enabled"] = true
+	}
+	meta, _ := object["meta"].(map[string]interface{})
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+	meta["nginx_online"] = true
+	object["meta"] = meta
+
+	s.objects[collection][s.nextID] = object
+
+	return s.nextID
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/api/users/me" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": 1})
+		return
+	}
+
+	// /api/nginx/<collection>[/<id>[/enable|/disable]]
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/nginx/"), "/")
+	collection := parts[0]
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			ids := make([]int, 0, len(s.objects[collection]))
+			for id := range s.objects[collection] {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+
+			list := make([]map[string]interface{}, 0, len(ids))
+			for _, id := range ids {
+				list = append(list, s.objects[collection][id])
+			}
+			writeJSON(w, http.StatusOK, list)
+		case http.MethodPost:
+			object := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorBody(err.Error()))
+				return
+			}
+			delete(object, "enabled")
+			id := s.add(collection, object)
+			writeJSON(w, http.StatusCreated, s.objects[collection][id])
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	object := s.objects[collection][id]
+	if err != nil || object == nil {
+		writeJSON(w, http.StatusNotFound, errorBody(fmt.Sprintf("%s %s not found", collection, parts[1])))
+		return
+	}
+
+	if len(parts) == 3 {
+		object["enabled"] = parts[2] == "enable"
+		writeJSON(w, http.StatusOK, true)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodPut:
+		update := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorBody(err.Error()))
+			return
+		}
+		for key, value := range update {
+			if key != "id" && key != "meta" && key != "enabled" {
+				object[key] = value
+			}
+		}
+		writeJSON(w, http.StatusOK, object)
+	case http.MethodDelete:
+		delete(s.objects[collection], id)
+		writeJSON(w, http.StatusOK, true)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func errorBody(message string) map[string]interface{} {
+	return map[string]interface{}{"error": map[string]interface{}{"message": message}}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}